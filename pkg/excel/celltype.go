@@ -0,0 +1,98 @@
+package excel
+
+import (
+	"strconv"
+	"time"
+)
+
+// excelEpoch is the day Excel's serial date numbering counts from. Excel
+// (incorrectly) treats 1900 as a leap year, but since that bug only affects
+// dates before March 1900, anchoring the epoch one day earlier at
+// 1899-12-30 yields the correct date for every real-world serial value.
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// resolveCellValue classifies a raw Cell into a typed CellValue, resolving
+// shared strings and inline strings and formatting booleans/dates the way a
+// user would expect to read them in Markdown.
+func resolveCellValue(c Cell, sharedStrings []string, styles *Styles) CellValue {
+	if c.Formula != "" {
+		// A formula cell carries its source in c.Formula and, optionally, a
+		// cached result in c.Val - but WriteExcel no longer writes that cache,
+		// so round-tripping only the source is what the markdown layer can
+		// actually rely on.
+		return CellValue{Kind: KindFormula, Raw: c.Val, Formatted: "=" + c.Formula}
+	}
+
+	switch c.Type {
+	case "s":
+		v := c.Val
+		if idx, err := strconv.Atoi(v); err == nil && idx >= 0 && idx < len(sharedStrings) {
+			v = sharedStrings[idx]
+		}
+		return CellValue{Kind: KindString, Raw: c.Val, Formatted: v}
+
+	case "inlineStr":
+		var v string
+		if c.Inline != nil {
+			v = c.Inline.T
+		}
+		return CellValue{Kind: KindString, Raw: v, Formatted: v}
+
+	case "b":
+		formatted := "FALSE"
+		if c.Val == "1" {
+			formatted = "TRUE"
+		}
+		return CellValue{Kind: KindBool, Raw: c.Val, Formatted: formatted}
+
+	case "str":
+		// Cached result of a formula; the formula source itself lives in c.Formula.
+		return CellValue{Kind: KindFormula, Raw: c.Val, Formatted: c.Val}
+
+	default:
+		// No "t" attribute means a plain number, unless the cell's style marks
+		// it as a date/time format, in which case Val is an Excel serial number.
+		if styles.IsDateFormat(c.StyleID) {
+			if formatted, ok := formatExcelSerialDate(c.Val); ok {
+				return CellValue{Kind: KindDate, Raw: c.Val, Formatted: formatted}
+			}
+		}
+		return CellValue{Kind: KindNumber, Raw: c.Val, Formatted: c.Val}
+	}
+}
+
+// formatExcelSerialDate converts an Excel serial date/time number (days
+// since 1899-12-30) into an ISO-8601 string. Whole-number serials format as
+// a plain date ("2024-03-01"); serials with a fractional day component
+// format with a time-of-day suffix ("2024-03-01T18:00:00").
+func formatExcelSerialDate(raw string) (string, bool) {
+	serial, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return "", false
+	}
+
+	days := int(serial)
+	fraction := serial - float64(days)
+	t := excelEpoch.AddDate(0, 0, days)
+
+	if fraction <= 0 {
+		return t.Format("2006-01-02"), true
+	}
+
+	seconds := int(fraction*86400 + 0.5) // round to the nearest second
+	t = t.Add(time.Duration(seconds) * time.Second)
+	return t.Format("2006-01-02T15:04:05"), true
+}
+
+// excelSerialFromISO converts an ISO-8601 date or date-time string (the form
+// formatExcelSerialDate produces) back into an Excel serial date number,
+// reporting false if iso isn't in a recognized layout.
+func excelSerialFromISO(iso string) (string, bool) {
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, iso); err == nil {
+			days := t.Sub(excelEpoch).Hours() / 24
+			return strconv.FormatFloat(days, 'f', -1, 64), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,169 @@
+package excel
+
+import "fmt"
+
+// mergesFromXML converts a worksheet's parsed <mergeCells> block, if any,
+// into the MergeRange list exposed on SheetData.
+func mergesFromXML(mc *MergeCellsXML) []MergeRange {
+	if mc == nil || len(mc.Cell) == 0 {
+		return nil
+	}
+	merges := make([]MergeRange, 0, len(mc.Cell))
+	for _, c := range mc.Cell {
+		start, end, ok := splitRangeRef(c.Ref)
+		if !ok {
+			continue
+		}
+		merges = append(merges, MergeRange{Start: start, End: end})
+	}
+	return merges
+}
+
+// freezePaneFromXML extracts the frozen pane's top-left cell reference from a
+// worksheet's parsed <sheetViews> block, if it has one, otherwise "".
+func freezePaneFromXML(sv *SheetViewsXML) string {
+	if sv == nil {
+		return ""
+	}
+	for _, view := range sv.SheetView {
+		if view.Pane != nil && view.Pane.State == "frozen" {
+			return view.Pane.TopLeftCell
+		}
+	}
+	return ""
+}
+
+// colWidthsFromXML converts a worksheet's parsed <cols> block, if any, into a
+// 0-based column index -> width map, expanding each <col min="..." max="..."/>
+// entry across the columns it spans.
+func colWidthsFromXML(cx *ColsXML) map[int]float64 {
+	if cx == nil || len(cx.Col) == 0 {
+		return nil
+	}
+	widths := make(map[int]float64)
+	for _, col := range cx.Col {
+		for c := col.Min; c <= col.Max; c++ {
+			widths[c-1] = col.Width
+		}
+	}
+	return widths
+}
+
+// rowHeightsFromXML converts a worksheet's parsed <sheetData> rows, if any
+// carry an explicit ht="..." height, into a 0-based row index -> height map.
+func rowHeightsFromXML(rows []Row) map[int]float64 {
+	var heights map[int]float64
+	for _, r := range rows {
+		if r.CustomHeight != "1" {
+			continue
+		}
+		if heights == nil {
+			heights = make(map[int]float64)
+		}
+		heights[r.R-1] = r.Ht
+	}
+	return heights
+}
+
+// splitRangeRef splits a mergeCell/range reference like "A1:B2" into its
+// start and end cell references, reporting false if ref isn't a valid range.
+func splitRangeRef(ref string) (start, end string, ok bool) {
+	for i := 0; i < len(ref); i++ {
+		if ref[i] == ':' {
+			return ref[:i], ref[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// SetMerge records a merged cell range, e.g. SetMerge("A1", "B2").
+func (s *Sheet) SetMerge(start, end string) {
+	s.merges = append(s.merges, MergeRange{Start: start, End: end})
+}
+
+// SetFreezePane marks topLeftCell (e.g. "A2") as the first scrollable cell,
+// freezing every row/column above/left of it.
+func (s *Sheet) SetFreezePane(topLeftCell string) {
+	s.freezePane = topLeftCell
+}
+
+// SetColWidth records an explicit width, in Excel's character-width units,
+// for the 0-based column col.
+func (s *Sheet) SetColWidth(col int, width float64) {
+	if s.colWidths == nil {
+		s.colWidths = make(map[int]float64)
+	}
+	s.colWidths[col] = width
+}
+
+// sheetViewsXML builds the <sheetViews> block for the sheet's frozen pane, or
+// nil if none was set.
+func (s *Sheet) sheetViewsXML() *SheetViewsXML {
+	if s.freezePane == "" {
+		return nil
+	}
+	row, col, ok := splitCellRef(s.freezePane)
+	if !ok {
+		return nil
+	}
+	return &SheetViewsXML{
+		SheetView: []SheetViewXML{{
+			Pane: &PaneXML{
+				XSplit:      float64(col),
+				YSplit:      float64(row),
+				TopLeftCell: s.freezePane,
+				State:       "frozen",
+			},
+		}},
+	}
+}
+
+// colsXML builds the <cols> block for the sheet's explicit column widths, or
+// nil if none were set.
+func (s *Sheet) colsXML() *ColsXML {
+	if len(s.colWidths) == 0 {
+		return nil
+	}
+	cx := &ColsXML{Col: make([]ColXML, 0, len(s.colWidths))}
+	for col, width := range s.colWidths {
+		cx.Col = append(cx.Col, ColXML{Min: col + 1, Max: col + 1, Width: width, CustomWidth: "1"})
+	}
+	return cx
+}
+
+// mergeCellsXML builds the <mergeCells> block for the sheet's merged ranges,
+// or nil if none were set.
+func (s *Sheet) mergeCellsXML() *MergeCellsXML {
+	if len(s.merges) == 0 {
+		return nil
+	}
+	mc := &MergeCellsXML{Count: len(s.merges), Cell: make([]MergeCellXML, len(s.merges))}
+	for i, m := range s.merges {
+		mc.Cell[i] = MergeCellXML{Ref: fmt.Sprintf("%s:%s", m.Start, m.End)}
+	}
+	return mc
+}
+
+// splitCellRef splits a cell reference like "B3" into its 0-based row and
+// column indices, reporting false if ref isn't a valid cell reference.
+func splitCellRef(ref string) (row, col int, ok bool) {
+	i := 0
+	for i < len(ref) && (ref[i] < '0' || ref[i] > '9') {
+		i++
+	}
+	if i == 0 || i == len(ref) {
+		return 0, 0, false
+	}
+	col = colRefToIndex(ref[:i])
+	rowNum := 0
+	for _, r := range ref[i:] {
+		if r < '0' || r > '9' {
+			return 0, 0, false
+		}
+		rowNum = rowNum*10 + int(r-'0')
+	}
+	if col < 0 || rowNum <= 0 {
+		return 0, 0, false
+	}
+	return rowNum - 1, col, true
+}
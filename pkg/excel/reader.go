@@ -5,148 +5,315 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 )
 
-// TODO: move shared Excel and XML struct definitions into a dedicated file (e.g. excel_types.go)
-// These types are reused across both reading and writing logic and should be isolated
-// to simplify maintenance and reduce duplication.
+// ReadExcel opens and reads a .xlsx spreadsheet file, returning its sheets and Cell data
+// as a slice of SheetData.
+func ReadExcel(filePath string) ([]SheetData, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the xlsx file: %w", err)
+	}
+	defer zr.Close()
 
-type SheetData struct {
-	Name string
-	Rows [][]string
-}
+	sharedStrings, err := loadSharedStrings(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
 
-type SheetXML struct {
-	Rows []Row `xml:"row"`
-}
+	styles, err := loadStyles(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
 
-type Row struct {
-	R     int    `xml:"r,attr"` // Row index (1-based)
-	Cells []Cell `xml:"c"`
-}
+	wbXML, err := loadWorkbookXML(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
 
-type Cell struct {
-	Ref  string `xml:"r,attr"`
-	Type string `xml:"t,attr,omitempty"`
-	Val  string `xml:"v"`
-}
+	rels, err := loadWorkbookRels(&zr.Reader)
+	if err != nil {
+		return nil, err
+	}
 
-// Worksheet Main XML structure for a single sheet (e.g., sheet1.xml)
-type Worksheet struct {
-	XMLName   xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
-	SheetData SheetXML `xml:"sheetData"`
-}
+	filesByName := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		filesByName[f.Name] = f
+	}
+
+	sheets := make([]SheetData, 0, len(wbXML.Sheets.Sheet))
+
+	// workbook.xml's <sheets> lists sheets in workbook (tab) order and carries
+	// their real display names; its r:id attributes resolve, via
+	// xl/_rels/workbook.xml.rels, to the worksheet part each sheet actually
+	// lives in. Reading sheets this way - rather than walking
+	// xl/worksheets/*.xml in zip/filename order and guessing a name from the
+	// filename - is required for files whose parts aren't named/ordered
+	// sheetN.xml in tab order (e.g. after a sheet reorder or delete in Excel).
+	for _, s := range wbXML.Sheets.Sheet {
+		target, ok := rels[s.RID]
+		if !ok {
+			return nil, fmt.Errorf("workbook.xml sheet %q references unknown relationship %q", s.Name, s.RID)
+		}
+
+		partPath := resolvePartPath(target)
+		f, ok := filesByName[partPath]
+		if !ok {
+			return nil, fmt.Errorf("worksheet part %q for sheet %q not found in archive", target, s.Name)
+		}
 
-// WorkbookXML lists all sheets and their relationships.
-type WorkbookXML struct {
-	XMLName xml.Name  `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
-	Sheets  SheetsXML `xml:"sheets"`
+		hyperlinks, err := loadSheetHyperlinks(filesByName, partPath)
+		if err != nil {
+			return nil, err
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+
+		xmlData, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+
+		var ws Worksheet
+		if err := xml.Unmarshal(xmlData, &ws); err != nil {
+			return nil, fmt.Errorf("failed to parse sheet XML: %w", err)
+		}
+
+		sheet := SheetData{Name: s.Name}
+		sheet.Merges = mergesFromXML(ws.MergeCells)
+		sheet.FreezePane = freezePaneFromXML(ws.SheetViews)
+		sheet.ColWidths = colWidthsFromXML(ws.Cols)
+		sheet.RowHeights = rowHeightsFromXML(ws.SheetData.Rows)
+		sheet.AutoFilter = ws.AutoFilter != nil
+
+		for _, r := range ws.SheetData.Rows {
+			var rowVals []string
+			var typedVals []TypedCell
+			for _, c := range r.Cells {
+				cv := resolveCellValue(c, sharedStrings, styles)
+				rowVals = append(rowVals, cv.Formatted)
+				link := hyperlinks[c.Ref]
+				typedVals = append(typedVals, TypedCell{Value: cv.Formatted, Kind: cv.Kind, Hyperlink: link.target, Tooltip: link.tooltip})
+
+				// A column's alignment is applied to every cell in it (see
+				// Sheet.SetColumnAlign), so the first alignment we see for a
+				// column is its alignment.
+				if col := colRefToIndex(c.Ref); col >= 0 {
+					growAlign(&sheet.ColumnAlign, col)
+					if sheet.ColumnAlign[col] == AlignDefault {
+						sheet.ColumnAlign[col] = styles.Alignment(c.StyleID)
+					}
+				}
+			}
+			sheet.Rows = append(sheet.Rows, rowVals)
+			sheet.Typed = append(sheet.Typed, typedVals)
+		}
+
+		sheets = append(sheets, sheet)
+	}
+
+	if activeIdx := activeSheetIndex(wbXML.BookViews); activeIdx >= 0 && activeIdx < len(sheets) {
+		sheets[activeIdx].Active = true
+	}
+
+	return sheets, nil
 }
 
-// SST (Shared String Table): Unique strings used in the workbook.
-type SST struct {
-	XMLName     xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main sst"`
-	Count       int      `xml:"count,attr"`
-	UniqueCount int      `xml:"uniqueCount,attr"`
-	SI          []SI     `xml:"si"`
+// activeSheetIndex extracts the workbook's active tab index from its parsed
+// <bookViews> block, defaulting to 0 (the first sheet, Excel's own default)
+// if the workbook has no <bookViews>/<workbookView> of its own.
+func activeSheetIndex(bv *BookViewsXML) int {
+	if bv == nil || len(bv.WorkbookView) == 0 {
+		return 0
+	}
+	return bv.WorkbookView[0].ActiveTab
 }
 
-type SI struct {
-	T string `xml:"t"` // Text
+// loadWorkbookXML parses the mandatory xl/workbook.xml part, which lists the
+// workbook's sheets in tab order together with their display names and
+// relationship IDs.
+func loadWorkbookXML(zr *zip.Reader) (*WorkbookXML, error) {
+	for _, f := range zr.File {
+		if f.Name != "xl/workbook.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open workbook.xml: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workbook.xml: %w", err)
+		}
+
+		var wbXML WorkbookXML
+		if err := xml.Unmarshal(data, &wbXML); err != nil {
+			return nil, fmt.Errorf("failed to parse workbook.xml: %w", err)
+		}
+		return &wbXML, nil
+	}
+	return nil, fmt.Errorf("xl/workbook.xml not found in archive")
 }
 
-type SheetsXML struct {
-	Sheet []SheetXMLInner `xml:"sheet"`
+// loadWorkbookRels parses the mandatory xl/_rels/workbook.xml.rels part into
+// a relationship ID -> target part map (e.g. "rId2" -> "worksheets/sheet3.xml"),
+// used to resolve a workbook.xml <sheet>'s r:id to the worksheet part it names.
+func loadWorkbookRels(zr *zip.Reader) (map[string]string, error) {
+	for _, f := range zr.File {
+		if f.Name != "xl/_rels/workbook.xml.rels" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open workbook.xml.rels: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workbook.xml.rels: %w", err)
+		}
+
+		var relsXML RelationshipsXML
+		if err := xml.Unmarshal(data, &relsXML); err != nil {
+			return nil, fmt.Errorf("failed to parse workbook.xml.rels: %w", err)
+		}
+
+		rels := make(map[string]string, len(relsXML.Relationship))
+		for _, r := range relsXML.Relationship {
+			rels[r.ID] = r.Target
+		}
+		return rels, nil
+	}
+	return nil, fmt.Errorf("xl/_rels/workbook.xml.rels not found in archive")
 }
 
-type SheetXMLInner struct {
-	Name    string `xml:"name,attr"`
-	SheetID int    `xml:"sheetId,attr"`
-	RID     string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+// hyperlinkTarget is a cell's resolved external hyperlink: the URL it points
+// at and its optional hover tooltip.
+type hyperlinkTarget struct {
+	target  string
+	tooltip string
 }
 
-// ReadExcel opens and reads a .xlsx spreadsheet file, returning its sheets and Cell data
-// as a slice of SheetData.
-func ReadExcel(filePath string) ([]SheetData, error) {
-	zr, err := zip.OpenReader(filePath)
+// loadSheetHyperlinks reads worksheetPartPath's own part (e.g.
+// "xl/worksheets/sheet1.xml") for a <hyperlinks> section, resolving each
+// entry's r:id against the sheet's own xl/worksheets/_rels/sheetN.xml.rels
+// part, and returns the result keyed by cell ref (e.g. "A1"). A sheet with
+// no hyperlinks, or no rels part, yields an empty (non-nil) map.
+func loadSheetHyperlinks(filesByName map[string]*zip.File, worksheetPartPath string) (map[string]hyperlinkTarget, error) {
+	links := make(map[string]hyperlinkTarget)
+
+	f, ok := filesByName[worksheetPartPath]
+	if !ok {
+		return links, nil
+	}
+	rc, err := f.Open()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open the xlsx file: %w", err)
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+
+	var ws Worksheet
+	if err := xml.Unmarshal(data, &ws); err != nil {
+		return nil, fmt.Errorf("failed to parse sheet XML: %w", err)
+	}
+	if ws.Hyperlinks == nil || len(ws.Hyperlinks.Hyperlink) == 0 {
+		return links, nil
 	}
-	defer zr.Close()
 
+	dir := strings.TrimSuffix(worksheetPartPath, ".xml")
+	slash := strings.LastIndex(dir, "/")
+	relsPath := dir[:slash+1] + "_rels/" + dir[slash+1:] + ".xml.rels"
+
+	relsFile, ok := filesByName[relsPath]
+	if !ok {
+		return links, nil
+	}
+	relsRC, err := relsFile.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", relsFile.Name, err)
+	}
+	relsData, err := io.ReadAll(relsRC)
+	relsRC.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", relsFile.Name, err)
+	}
+
+	var relsXML RelationshipsXML
+	if err := xml.Unmarshal(relsData, &relsXML); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", relsFile.Name, err)
+	}
+	targets := make(map[string]string, len(relsXML.Relationship))
+	for _, r := range relsXML.Relationship {
+		targets[r.ID] = r.Target
+	}
+
+	for _, h := range ws.Hyperlinks.Hyperlink {
+		if target, ok := targets[h.RID]; ok {
+			links[h.Ref] = hyperlinkTarget{target: target, tooltip: h.Tooltip}
+		}
+	}
+	return links, nil
+}
+
+// resolvePartPath turns a relationship Target (as found in a .rels file, and
+// always relative to the directory the .rels file's subject lives in - here
+// "xl/") into the full, zip-internal part path. A Target is left untouched
+// if it's already package-absolute (leading "/").
+func resolvePartPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return "xl/" + target
+}
+
+// loadSharedStrings parses xl/sharedStrings.xml, if present, and returns its entries
+// in order so that a Cell's numeric string index (t="s") can be resolved by position.
+func loadSharedStrings(zr *zip.Reader) ([]string, error) {
 	var sharedStrings []string
-	var sheets []SheetData
 
-	// Locate and parse the sharedStrings.xml file if present.
-	// This file contains all unique text strings used throughout the workbook.
 	for _, f := range zr.File {
 		if f.Name == "xl/sharedStrings.xml" {
-			rc, _ := f.Open()
-			defer rc.Close()
-			data, _ := io.ReadAll(rc)
-			type sst struct {
-				SI []struct {
-					T string `xml:"t"`
-				} `xml:"si"`
-			}
-			var s sst
-			xml.Unmarshal(data, &s)
-			for _, v := range s.SI {
-				sharedStrings = append(sharedStrings, v.T)
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to open sharedStrings.xml: %w", err)
 			}
-			break
-		}
-	}
-	// Each worksheet is stored as an XML file under xl/worksheets/, typically named sheet1.xml,
-	// sheet2.xml, and so on. The following loop extracts each of these sheets and converts its
-	// XML representation into a SheetData structure. Cells that reference shared strings are
-	// replaced with their resolved text values.
-	for _, f := range zr.File {
-		if strings.HasPrefix(f.Name, "xl/worksheets/sheet") && strings.HasSuffix(f.Name, ".xml") {
-			rc, _ := f.Open()
 			defer rc.Close()
 
-			xmlData, _ := io.ReadAll(rc)
-			var sx SheetXML
-			if err := xml.Unmarshal(xmlData, &sx); err != nil {
-				return nil, fmt.Errorf("failed to parse sheet XML: %w", err)
+			data, err := io.ReadAll(rc)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read sharedStrings.xml: %w", err)
 			}
 
-			var sheet SheetData
-			sheet.Name = guessSheetName(f.Name)
-
-			for _, r := range sx.Rows {
-				var rowVals []string
-				for _, c := range r.Cells {
-					v := c.Val
-					// When a Cell’s type attribute is “s”, its value represents an index
-					// into the shared strings table. In that case, we replace the numeric
-					// index with the corresponding string value.
-					if c.Type == "s" {
-						idx, _ := strconv.Atoi(v)
-						if idx < len(sharedStrings) {
-							v = sharedStrings[idx]
-						}
-					}
-					rowVals = append(rowVals, v)
-				}
-				sheet.Rows = append(sheet.Rows, rowVals)
+			var sst SST
+			if err := xml.Unmarshal(data, &sst); err != nil {
+				return nil, fmt.Errorf("failed to parse sharedStrings.xml: %w", err)
 			}
-
-			sheets = append(sheets, sheet)
+			for _, si := range sst.SI {
+				sharedStrings = append(sharedStrings, si.T)
+			}
+			break
 		}
 	}
 
-	return sheets, nil
+	return sharedStrings, nil
 }
 
-// guessSheetName extracts a simple worksheet name from its internal
-// path inside the XLSX archive, removing the directory and ".xml"
-// extension (e.g., "xl/worksheets/sheet1.xml" → "Sheet1").
-func guessSheetName(path string) string {
-	name := strings.TrimPrefix(path, "xl/worksheets/")
-	name = strings.TrimSuffix(name, ".xml")
-	return strings.Title(name)
+// growAlign extends *align with AlignDefault entries, if needed, so index col
+// can be read or written.
+func growAlign(align *[]Align, col int) {
+	for len(*align) <= col {
+		*align = append(*align, AlignDefault)
+	}
 }
@@ -0,0 +1,186 @@
+package excel
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// OnRowFunc is called once per parsed row while streaming a worksheet.
+// sheet is the worksheet's display name, rowIdx is its 1-based row index,
+// and cells holds the resolved, shared-string-expanded cell values for that
+// row (sparse trailing cells are omitted, matching SheetData.Rows).
+type OnRowFunc func(sheet string, rowIdx int, cells []string) error
+
+// StreamExcel reads a .xlsx file one row at a time instead of buffering each
+// worksheet's XML into memory, so memory use stays roughly O(unique shared
+// strings) rather than O(file size). It is meant for workbooks too large to
+// comfortably load via ReadExcel. The shared strings table is still read in
+// full up front, since cell values can't be resolved without it, but
+// worksheet XML is walked token-by-token via xml.Decoder.
+func StreamExcel(filePath string, onRow OnRowFunc) error {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open the xlsx file: %w", err)
+	}
+	defer zr.Close()
+
+	sharedStrings, err := loadSharedStrings(&zr.Reader)
+	if err != nil {
+		return err
+	}
+
+	wbXML, err := loadWorkbookXML(&zr.Reader)
+	if err != nil {
+		return err
+	}
+
+	rels, err := loadWorkbookRels(&zr.Reader)
+	if err != nil {
+		return err
+	}
+
+	filesByName := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		filesByName[f.Name] = f
+	}
+
+	// Sheets are streamed in workbook.xml's tab order, by the real display
+	// name, for the same reason ReadExcel resolves them this way rather than
+	// walking xl/worksheets/*.xml in zip/filename order.
+	for _, s := range wbXML.Sheets.Sheet {
+		target, ok := rels[s.RID]
+		if !ok {
+			return fmt.Errorf("workbook.xml sheet %q references unknown relationship %q", s.Name, s.RID)
+		}
+
+		f, ok := filesByName[resolvePartPath(target)]
+		if !ok {
+			return fmt.Errorf("worksheet part %q for sheet %q not found in archive", target, s.Name)
+		}
+
+		if err := streamWorksheet(f, s.Name, sharedStrings, onRow); err != nil {
+			return fmt.Errorf("failed to stream sheet %s: %w", s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// streamWorksheet decodes a single worksheet's <row>/<c>/<v>/<is> elements
+// incrementally, invoking onRow as each <row> closes.
+func streamWorksheet(f *zip.File, sheetName string, sharedStrings []string, onRow OnRowFunc) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(rc)
+
+	var (
+		rowIdx   int
+		cells    []string
+		cellRef  string
+		cellType string
+		inValue  bool
+		inInline bool
+		inIST    bool
+	)
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode worksheet XML: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				rowIdx = 0
+				for _, a := range t.Attr {
+					if a.Name.Local == "r" {
+						rowIdx, _ = strconv.Atoi(a.Value)
+					}
+				}
+				cells = nil
+			case "c":
+				cellRef, cellType = "", ""
+				for _, a := range t.Attr {
+					switch a.Name.Local {
+					case "r":
+						cellRef = a.Value
+					case "t":
+						cellType = a.Value
+					}
+				}
+			case "v":
+				inValue = true
+			case "is":
+				inInline = true
+			case "t":
+				if inInline {
+					inIST = true
+				}
+			}
+		case xml.CharData:
+			if inValue || inIST {
+				v := string(t)
+				if cellType == "s" {
+					if idx, convErr := strconv.Atoi(v); convErr == nil && idx >= 0 && idx < len(sharedStrings) {
+						v = sharedStrings[idx]
+					}
+				}
+				setCellAt(&cells, colRefToIndex(cellRef), v)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "v":
+				inValue = false
+			case "t":
+				inIST = false
+			case "is":
+				inInline = false
+			case "row":
+				if err := onRow(sheetName, rowIdx, cells); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// setCellAt places v at the given 0-based column index within cells,
+// growing the slice with empty strings as needed. A negative index (an
+// unparsable or missing cell reference) is ignored.
+func setCellAt(cells *[]string, col int, v string) {
+	if col < 0 {
+		return
+	}
+	for len(*cells) <= col {
+		*cells = append(*cells, "")
+	}
+	(*cells)[col] = v
+}
+
+// colRefToIndex converts an Excel cell reference such as "C5" to its 0-based
+// column index ("A" -> 0, "Z" -> 25, "AA" -> 26). It returns -1 if ref has no
+// recognizable column letters.
+func colRefToIndex(ref string) int {
+	index := -1
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		index = (index+1)*26 + int(ch-'A')
+	}
+	return index
+}
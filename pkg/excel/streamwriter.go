@@ -0,0 +1,720 @@
+package excel
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// xmlDeclaration is prepended to every part this file writes by hand, in
+// place of encoding/xml's xml.Header, since none of this file's XML is
+// produced via xml.Marshal/xml.Encoder.
+const xmlDeclaration = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`
+
+// StreamWriter is a streaming counterpart to WriteExcel/Workbook: rather than
+// buffering every sheet's cells and shared strings in memory before
+// marshalling whole Worksheet structs, it writes each row's XML to its
+// worksheet's zip entry as soon as it's appended, and spills unique shared
+// strings to a temp file instead of holding them in a slice. Memory use
+// stays roughly O(sheet/merge/column count), not O(rows). Open one with
+// NewStreamWriter, add sheets with AppendSheet, write rows with
+// SheetStream.WriteRow, then call Close to finalize the archive.
+type StreamWriter struct {
+	zw               *zip.Writer
+	file             *os.File
+	sst              *sstBuilder
+	sheetNames       []string
+	current          *SheetStream
+	closed           bool
+	activeSheetIndex int
+}
+
+// NewStreamWriter creates filePath and returns a StreamWriter ready to
+// receive sheets via AppendSheet.
+func NewStreamWriter(filePath string) (*StreamWriter, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create xlsx file: %w", err)
+	}
+
+	sst, err := newSSTBuilder()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &StreamWriter{zw: zip.NewWriter(file), file: file, sst: sst}, nil
+}
+
+// SetActiveSheetIndex marks the sheet at idx (0-based, in AppendSheet order)
+// as the one Excel should show as selected when the workbook is opened, via
+// xl/workbook.xml's <bookViews><workbookView activeTab="idx"/>.
+func (sw *StreamWriter) SetActiveSheetIndex(idx int) {
+	sw.activeSheetIndex = idx
+}
+
+// AppendSheet finishes the previously appended sheet, if any, then opens a
+// new worksheet named name and returns a SheetStream for writing its rows.
+// Sheets are written to the workbook in the order they're appended.
+func (sw *StreamWriter) AppendSheet(name string) (*SheetStream, error) {
+	if sw.current != nil {
+		if err := sw.current.finish(); err != nil {
+			return nil, err
+		}
+	}
+
+	idx := len(sw.sheetNames) + 1
+	w, err := sw.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", idx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add worksheet part: %w", err)
+	}
+	sw.sheetNames = append(sw.sheetNames, name)
+
+	ss := &SheetStream{w: w, sst: sw.sst, zw: sw.zw, sheetIndex: idx}
+	sw.current = ss
+	return ss, nil
+}
+
+// Close finishes the last appended sheet, writes the workbook-level parts
+// ([Content_Types].xml, the package and workbook rels, xl/workbook.xml,
+// xl/styles.xml, and xl/sharedStrings.xml, the last built from the shared
+// string spill file) now that every sheet name is known, and finalizes the
+// zip archive. It is safe to call more than once. The zip writer, shared-
+// string spill file, and output file are released via deferred cleanup even
+// if one of these steps fails, so a write error never leaks them.
+func (sw *StreamWriter) Close() (err error) {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+
+	defer func() {
+		if zerr := sw.zw.Close(); err == nil && zerr != nil {
+			err = fmt.Errorf("failed to finalize xlsx zip: %w", zerr)
+		}
+		if cerr := sw.sst.cleanup(); err == nil && cerr != nil {
+			err = cerr
+		}
+		if ferr := sw.file.Close(); err == nil && ferr != nil {
+			err = ferr
+		}
+	}()
+
+	if sw.current != nil {
+		if err = sw.current.finish(); err != nil {
+			return err
+		}
+		sw.current = nil
+	}
+
+	if err = sw.writeContentTypes(); err != nil {
+		return err
+	}
+	if err = sw.writePackageRels(); err != nil {
+		return err
+	}
+	if err = sw.writeStyles(); err != nil {
+		return err
+	}
+	if err = sw.writeWorkbookRels(); err != nil {
+		return err
+	}
+	if err = sw.writeWorkbookXML(); err != nil {
+		return err
+	}
+	err = sw.sst.writeTo(sw.zw)
+	return err
+}
+
+func (sw *StreamWriter) writeContentTypes() error {
+	w, err := sw.zw.Create("[Content_Types].xml")
+	if err != nil {
+		return fmt.Errorf("failed to add content types part: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xmlDeclaration)
+	sb.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	sb.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	sb.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	sb.WriteString(`<Override PartName="/xl/sharedStrings.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>`)
+	for i := range sw.sheetNames {
+		fmt.Fprintf(&sb, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	sb.WriteString(`</Types>`)
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func (sw *StreamWriter) writePackageRels() error {
+	w, err := sw.zw.Create("_rels/.rels")
+	if err != nil {
+		return fmt.Errorf("failed to add package rels part: %w", err)
+	}
+	_, err = io.WriteString(w, xmlDeclaration+`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`)
+	return err
+}
+
+func (sw *StreamWriter) writeStyles() error {
+	w, err := sw.zw.Create("xl/styles.xml")
+	if err != nil {
+		return fmt.Errorf("failed to add styles part: %w", err)
+	}
+	_, err = io.WriteString(w, minimalStylesXML())
+	return err
+}
+
+func (sw *StreamWriter) writeWorkbookRels() error {
+	w, err := sw.zw.Create("xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return fmt.Errorf("failed to add workbook rels part: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xmlDeclaration)
+	sb.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range sw.sheetNames {
+		fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sw.sheetNames)+1)
+	fmt.Fprintf(&sb, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>`, len(sw.sheetNames)+2)
+	sb.WriteString(`</Relationships>`)
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+func (sw *StreamWriter) writeWorkbookXML() error {
+	w, err := sw.zw.Create("xl/workbook.xml")
+	if err != nil {
+		return fmt.Errorf("failed to add workbook part: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xmlDeclaration)
+	sb.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	if sw.activeSheetIndex != 0 {
+		fmt.Fprintf(&sb, `<bookViews><workbookView activeTab="%d"/></bookViews>`, sw.activeSheetIndex)
+	}
+	sb.WriteString(`<sheets>`)
+	for i, name := range sw.sheetNames {
+		if name == "" {
+			name = fmt.Sprintf("Sheet%d", i+1)
+		}
+		fmt.Fprintf(&sb, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXMLAttr(name), i+1, i+1)
+	}
+	sb.WriteString(`</sheets></workbook>`)
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// SheetStream writes one worksheet's rows directly to its zip entry,
+// obtained from StreamWriter.AppendSheet. Call SetColumnAlign/SetMerge/
+// SetFreezePane/SetColWidth, if needed, before the first WriteRow - the
+// worksheet's <sheetViews>/<cols> prologue is flushed on the first row (or
+// on Close, for an empty sheet), so layout set afterward is silently
+// ineffective.
+type SheetStream struct {
+	w   io.Writer
+	sst *sstBuilder
+
+	zw         *zip.Writer
+	sheetIndex int
+
+	rowIdx          int
+	prologueWritten bool
+
+	colAlign   []Align
+	merges     []MergeRange
+	freezePane string
+	colWidths  map[int]float64
+	rowHeights map[int]float64
+	hyperlinks []hyperlinkEntry
+	autoFilter bool
+	maxCol     int
+}
+
+// hyperlinkEntry records one cell's external hyperlink until finish() can
+// assign it an rId and emit the worksheet's <hyperlinks> section and
+// xl/worksheets/_rels/sheetN.xml.rels part.
+type hyperlinkEntry struct {
+	ref     string
+	target  string
+	tooltip string
+}
+
+// SetColumnAlign records the GFM/Excel horizontal alignment for col, same as
+// Sheet.SetColumnAlign.
+func (ss *SheetStream) SetColumnAlign(col int, align Align) {
+	growAlign(&ss.colAlign, col)
+	ss.colAlign[col] = align
+}
+
+// SetMerge records a merged cell range, e.g. SetMerge("A1", "B2").
+func (ss *SheetStream) SetMerge(start, end string) {
+	ss.merges = append(ss.merges, MergeRange{Start: start, End: end})
+}
+
+// SetFreezePane marks topLeftCell (e.g. "A2") as the first scrollable cell.
+func (ss *SheetStream) SetFreezePane(topLeftCell string) {
+	ss.freezePane = topLeftCell
+}
+
+// SetColWidth records an explicit width for the 0-based column col.
+func (ss *SheetStream) SetColWidth(col int, width float64) {
+	if ss.colWidths == nil {
+		ss.colWidths = make(map[int]float64)
+	}
+	ss.colWidths[col] = width
+}
+
+// SetRowHeight records an explicit height, in points, for the 0-based row
+// row.
+func (ss *SheetStream) SetRowHeight(row int, height float64) {
+	if ss.rowHeights == nil {
+		ss.rowHeights = make(map[int]float64)
+	}
+	ss.rowHeights[row] = height
+}
+
+// SetAutoFilter enables an <autoFilter> over the sheet's full data range,
+// giving every column a filter dropdown when opened in Excel.
+func (ss *SheetStream) SetAutoFilter(enabled bool) {
+	ss.autoFilter = enabled
+}
+
+// WriteRow appends one row of plain-string cell values, resolving each
+// non-empty value through the workbook's shared string table and writing
+// its <row>/<c>/<v> XML straight to the worksheet's zip entry. Every cell is
+// written as a shared string (t="s") - use WriteTypedRow to preserve
+// numbers, booleans, dates, and formulas as their native OOXML cell types.
+func (ss *SheetStream) WriteRow(cells []string) error {
+	if err := ss.writePrologue(); err != nil {
+		return err
+	}
+	ss.rowIdx++
+	ss.growMaxCol(len(cells))
+
+	var sb strings.Builder
+	sb.WriteString(ss.rowOpenTag())
+	for col, val := range cells {
+		if val == "" {
+			continue // Excel omits empty cells in the XML
+		}
+		idx, err := ss.sst.indexOf(val)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&sb, `<c r="%s%d" t="s" s="%d"><v>%d</v></c>`, toColName(col), ss.rowIdx, ss.styleFor(col), idx)
+	}
+	sb.WriteString(`</row>`)
+
+	_, err := io.WriteString(ss.w, sb.String())
+	return err
+}
+
+// WriteTypedRow appends one row of TypedCell values, writing each cell as
+// its native OOXML type: a Number as a bare numeric <v>, a Bool as t="b", a
+// Date as an Excel serial number under the built-in date style, a Formula
+// as <f>source</f> (without a cached <v>, so Excel recalculates it on
+// open), and a String as a shared string, same as WriteRow.
+func (ss *SheetStream) WriteTypedRow(cells []TypedCell) error {
+	if err := ss.writePrologue(); err != nil {
+		return err
+	}
+	ss.rowIdx++
+	ss.growMaxCol(len(cells))
+
+	var sb strings.Builder
+	sb.WriteString(ss.rowOpenTag())
+	for col, cell := range cells {
+		if cell.Value == "" && cell.Kind != KindFormula {
+			continue // Excel omits empty cells in the XML
+		}
+		ref := fmt.Sprintf("%s%d", toColName(col), ss.rowIdx)
+		if cell.Hyperlink != "" {
+			ss.hyperlinks = append(ss.hyperlinks, hyperlinkEntry{ref: ref, target: cell.Hyperlink, tooltip: cell.Tooltip})
+		}
+
+		switch cell.Kind {
+		case KindBool:
+			val := "0"
+			if cell.Value == "TRUE" {
+				val = "1"
+			}
+			fmt.Fprintf(&sb, `<c r="%s" t="b" s="%d"><v>%s</v></c>`, ref, ss.styleFor(col), val)
+
+		case KindDate:
+			serial, ok := excelSerialFromISO(cell.Value)
+			if !ok {
+				serial = "0"
+			}
+			styleID := ss.dateStyleFor(col)
+			fmt.Fprintf(&sb, `<c r="%s" s="%d"><v>%s</v></c>`, ref, styleID, serial)
+
+		case KindFormula:
+			formula := escapeXMLText(strings.TrimPrefix(cell.Value, "="))
+			fmt.Fprintf(&sb, `<c r="%s" s="%d"><f>%s</f></c>`, ref, ss.styleFor(col), formula)
+
+		case KindNumber:
+			fmt.Fprintf(&sb, `<c r="%s" s="%d"><v>%s</v></c>`, ref, ss.styleFor(col), cell.Value)
+
+		default: // KindString
+			idx, err := ss.sst.indexOf(cell.Value)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&sb, `<c r="%s" t="s" s="%d"><v>%d</v></c>`, ref, ss.styleFor(col), idx)
+		}
+	}
+	sb.WriteString(`</row>`)
+
+	_, err := io.WriteString(ss.w, sb.String())
+	return err
+}
+
+// rowOpenTag renders the current row's opening "<row r="...">" tag,
+// including ht/customHeight attributes if an explicit height was set for it
+// via SetRowHeight.
+func (ss *SheetStream) rowOpenTag() string {
+	height, ok := ss.rowHeights[ss.rowIdx-1]
+	if !ok {
+		return fmt.Sprintf(`<row r="%d">`, ss.rowIdx)
+	}
+	return fmt.Sprintf(`<row r="%d" ht="%v" customHeight="1">`, ss.rowIdx, height)
+}
+
+// growMaxCol records colCount's last column index as ss.maxCol if it's
+// further right than anything seen so far, so finish() can size the
+// <autoFilter> ref over the sheet's full column range.
+func (ss *SheetStream) growMaxCol(colCount int) {
+	if n := colCount - 1; n > ss.maxCol {
+		ss.maxCol = n
+	}
+}
+
+// styleFor resolves the cellXfs index a non-date cell in col should use: the
+// column's alignment override, if any, else a bold header style for the
+// first row and the plain default style for every row after it.
+func (ss *SheetStream) styleFor(col int) int {
+	align := AlignDefault
+	if col < len(ss.colAlign) {
+		align = ss.colAlign[col]
+	}
+	if styleID := alignStyleID(align); styleID != AutoStyle {
+		return styleID
+	}
+	if ss.rowIdx == 1 {
+		return StyleHeader
+	}
+	return StyleDefault
+}
+
+// dateStyleFor is styleFor's counterpart for KindDate cells: the column's
+// alignment override still wins, but the Kind-based fallback is the
+// built-in date style rather than header/default.
+func (ss *SheetStream) dateStyleFor(col int) int {
+	align := AlignDefault
+	if col < len(ss.colAlign) {
+		align = ss.colAlign[col]
+	}
+	if styleID := alignStyleID(align); styleID != AutoStyle {
+		return styleID
+	}
+	return StyleDate
+}
+
+// writePrologue writes the XML declaration, <worksheet>, any <sheetViews>/
+// <cols> layout, and the opening <sheetData> tag, exactly once.
+func (ss *SheetStream) writePrologue() error {
+	if ss.prologueWritten {
+		return nil
+	}
+	ss.prologueWritten = true
+
+	var sb strings.Builder
+	sb.WriteString(xmlDeclaration)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	sb.WriteString(sheetViewsTag(ss.freezePane))
+	sb.WriteString(colsTag(ss.colWidths))
+	sb.WriteString(`<sheetData>`)
+
+	_, err := io.WriteString(ss.w, sb.String())
+	return err
+}
+
+// finish closes out the worksheet's <sheetData>, emits <autoFilter>,
+// <mergeCells>, and <hyperlinks> if any were set, and closes </worksheet>.
+// It flushes the prologue first, so an empty sheet still produces a
+// well-formed, if row-less, worksheet part. The worksheet's backing
+// xl/worksheets/_rels/sheetN.xml.rels part, if it needs one, is written
+// last, once ss.w itself is fully written and done with - zw.Create-ing
+// that new zip entry implicitly closes whatever writer was previously
+// open, which would otherwise cut ss.w off mid-part.
+func (ss *SheetStream) finish() error {
+	if err := ss.writePrologue(); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`</sheetData>`)
+	sb.WriteString(autoFilterTag(ss.autoFilter, ss.maxCol, ss.rowIdx))
+	sb.WriteString(mergeCellsTag(ss.merges))
+	sb.WriteString(hyperlinksTag(ss.hyperlinks))
+	sb.WriteString(`</worksheet>`)
+
+	if _, err := io.WriteString(ss.w, sb.String()); err != nil {
+		return err
+	}
+
+	return ss.writeHyperlinkRels()
+}
+
+// hyperlinksTag renders the <hyperlinks> section referencing ss.hyperlinks'
+// assigned rIds (rId1, rId2, ... in append order), or "" if the sheet has
+// no hyperlinks.
+func hyperlinksTag(hyperlinks []hyperlinkEntry) string {
+	if len(hyperlinks) == 0 {
+		return ""
+	}
+	var links strings.Builder
+	links.WriteString(`<hyperlinks>`)
+	for i, h := range hyperlinks {
+		fmt.Fprintf(&links, `<hyperlink ref="%s" r:id="rId%d"`, h.ref, i+1)
+		if h.tooltip != "" {
+			fmt.Fprintf(&links, ` tooltip="%s"`, escapeXMLAttr(h.tooltip))
+		}
+		links.WriteString(`/>`)
+	}
+	links.WriteString(`</hyperlinks>`)
+	return links.String()
+}
+
+// writeHyperlinkRels writes the sheet's xl/worksheets/_rels/sheetN.xml.rels
+// part declaring ss.hyperlinks as external-target relationships, under the
+// same rId1, rId2, ... assignment hyperlinksTag used - or does nothing if
+// the sheet has no hyperlinks.
+func (ss *SheetStream) writeHyperlinkRels() error {
+	if len(ss.hyperlinks) == 0 {
+		return nil
+	}
+
+	var rels strings.Builder
+	rels.WriteString(xmlDeclaration)
+	rels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, h := range ss.hyperlinks {
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="%s" TargetMode="External"/>`, i+1, escapeXMLAttr(h.target))
+	}
+	rels.WriteString(`</Relationships>`)
+
+	w, err := ss.zw.Create(fmt.Sprintf("xl/worksheets/_rels/sheet%d.xml.rels", ss.sheetIndex))
+	if err != nil {
+		return fmt.Errorf("failed to add worksheet rels part: %w", err)
+	}
+	_, err = io.WriteString(w, rels.String())
+	return err
+}
+
+// sheetViewsTag renders the <sheetViews> element for a frozen pane at
+// topLeftCell, or "" if topLeftCell is empty or not a valid cell reference.
+func sheetViewsTag(topLeftCell string) string {
+	if topLeftCell == "" {
+		return ""
+	}
+	row, col, ok := splitCellRef(topLeftCell)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(`<sheetViews><sheetView><pane xSplit="%d" ySplit="%d" topLeftCell="%s" activePane="%s" state="frozen"/></sheetView></sheetViews>`, col, row, topLeftCell, activePaneFor(col, row))
+}
+
+// activePaneFor names the pane Excel should treat as active for a frozen
+// split at (xSplit, ySplit), following the same convention excelize's
+// SetPanes uses: the pane below/right of whichever axes are actually split.
+func activePaneFor(xSplit, ySplit int) string {
+	switch {
+	case xSplit > 0 && ySplit > 0:
+		return "bottomRight"
+	case xSplit > 0:
+		return "topRight"
+	default:
+		return "bottomLeft"
+	}
+}
+
+// autoFilterTag renders the <autoFilter> element over the sheet's data
+// range ("A1:<lastCol><lastRow>"), or "" if autoFilter is disabled or the
+// sheet has no rows.
+func autoFilterTag(enabled bool, maxCol, lastRow int) string {
+	if !enabled || lastRow == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`<autoFilter ref="A1:%s%d"/>`, toColName(maxCol), lastRow)
+}
+
+// colsTag renders the <cols> element for the given 0-based column -> width
+// map, in ascending column order, or "" if it's empty.
+func colsTag(colWidths map[int]float64) string {
+	if len(colWidths) == 0 {
+		return ""
+	}
+	cols := make([]int, 0, len(colWidths))
+	for col := range colWidths {
+		cols = append(cols, col)
+	}
+	sort.Ints(cols)
+
+	var sb strings.Builder
+	sb.WriteString(`<cols>`)
+	for _, col := range cols {
+		fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%v" customWidth="1"/>`, col+1, col+1, colWidths[col])
+	}
+	sb.WriteString(`</cols>`)
+	return sb.String()
+}
+
+// mergeCellsTag renders the <mergeCells> element for merges, or "" if empty.
+func mergeCellsTag(merges []MergeRange) string {
+	if len(merges) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<mergeCells count="%d">`, len(merges))
+	for _, m := range merges {
+		fmt.Fprintf(&sb, `<mergeCell ref="%s:%s"/>`, m.Start, m.End)
+	}
+	sb.WriteString(`</mergeCells>`)
+	return sb.String()
+}
+
+// sstBuilder incrementally builds a shared string table: indexOf dedupes and
+// assigns each unique string an index, spilling the string itself (as a
+// length-prefixed record) to a temp file instead of keeping it in memory, so
+// writeTo can stream xl/sharedStrings.xml out of the spill file rather than
+// a slice built up over the whole write.
+type sstBuilder struct {
+	index  map[string]int
+	spill  *os.File
+	total  int // total references, including repeats
+	unique int // unique strings, i.e. len(index)
+}
+
+// newSSTBuilder creates the on-disk spill file a new sstBuilder writes
+// unique strings to as they're first seen.
+func newSSTBuilder() (*sstBuilder, error) {
+	spill, err := os.CreateTemp("", "xlmd-sst-*.bin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shared-string spill file: %w", err)
+	}
+	return &sstBuilder{index: make(map[string]int), spill: spill}, nil
+}
+
+// indexOf returns s's shared-string index, assigning and spilling it as the
+// next index if s hasn't been seen before.
+func (b *sstBuilder) indexOf(s string) (int, error) {
+	b.total++
+	if idx, ok := b.index[s]; ok {
+		return idx, nil
+	}
+
+	idx := b.unique
+	b.index[s] = idx
+	b.unique++
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(s)))
+	if _, err := b.spill.Write(length[:]); err != nil {
+		return 0, fmt.Errorf("failed to spill shared string: %w", err)
+	}
+	if _, err := io.WriteString(b.spill, s); err != nil {
+		return 0, fmt.Errorf("failed to spill shared string: %w", err)
+	}
+
+	return idx, nil
+}
+
+// writeTo streams xl/sharedStrings.xml - the final count/uniqueCount header,
+// then each spilled string re-read and XML-escaped in turn - into zw.
+func (b *sstBuilder) writeTo(zw *zip.Writer) error {
+	w, err := zw.Create("xl/sharedStrings.xml")
+	if err != nil {
+		return fmt.Errorf("failed to add sharedStrings part: %w", err)
+	}
+
+	fmt.Fprintf(w, `%s<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="%d" uniqueCount="%d">`, xmlDeclaration, b.total, b.unique)
+
+	if _, err := b.spill.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind shared-string spill file: %w", err)
+	}
+	var length [4]byte
+	for i := 0; i < b.unique; i++ {
+		if _, err := io.ReadFull(b.spill, length[:]); err != nil {
+			return fmt.Errorf("failed to read shared-string spill file: %w", err)
+		}
+		buf := make([]byte, binary.LittleEndian.Uint32(length[:]))
+		if _, err := io.ReadFull(b.spill, buf); err != nil {
+			return fmt.Errorf("failed to read shared-string spill file: %w", err)
+		}
+		if _, err := io.WriteString(w, "<si><t>"+escapeXMLText(string(buf))+"</t></si>"); err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, `</sst>`)
+	return err
+}
+
+// cleanup closes and removes the spill file.
+func (b *sstBuilder) cleanup() error {
+	name := b.spill.Name()
+	if err := b.spill.Close(); err != nil {
+		return err
+	}
+	return os.Remove(name)
+}
+
+// escapeXMLText escapes s for use as XML element text/character data.
+func escapeXMLText(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '&':
+			sb.WriteString("&amp;")
+		case '<':
+			sb.WriteString("&lt;")
+		case '>':
+			sb.WriteString("&gt;")
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// escapeXMLAttr escapes s for use inside a double-quoted XML attribute value.
+func escapeXMLAttr(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '&':
+			sb.WriteString("&amp;")
+		case '<':
+			sb.WriteString("&lt;")
+		case '>':
+			sb.WriteString("&gt;")
+		case '"':
+			sb.WriteString("&quot;")
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
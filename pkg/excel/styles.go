@@ -0,0 +1,172 @@
+package excel
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// StylesXML models the parts of xl/styles.xml that xlmd cares about: the
+// custom number formats and the cell format (xf) records that cells
+// reference by index via their "s" attribute.
+type StylesXML struct {
+	XMLName xml.Name    `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main styleSheet"`
+	NumFmts []NumFmtXML `xml:"numFmts>numFmt"`
+	CellXfs []CellXfXML `xml:"cellXfs>xf"`
+}
+
+// NumFmtXML represents a custom <numFmt> definition, mapping a numFmtId to
+// its format code (e.g. "yyyy-mm-dd").
+type NumFmtXML struct {
+	NumFmtID   int    `xml:"numFmtId,attr"`
+	FormatCode string `xml:"formatCode,attr"`
+}
+
+// CellXfXML represents a single <xf> entry in <cellXfs>; a Cell's "s" attribute
+// indexes into this list to find the numFmtId/alignment that governs its display.
+type CellXfXML struct {
+	NumFmtID  int           `xml:"numFmtId,attr"`
+	Alignment *AlignmentXML `xml:"alignment"`
+}
+
+// AlignmentXML represents the <alignment horizontal="left|center|right"/> child
+// of a <xf> entry.
+type AlignmentXML struct {
+	Horizontal string `xml:"horizontal,attr"`
+}
+
+// Styles is the decoded, queryable form of xl/styles.xml used to tell whether
+// a numeric cell actually represents a date, and what horizontal alignment a
+// cell's column was written with.
+type Styles struct {
+	customNumFmts map[int]string // numFmtId -> formatCode, for ids >= 164
+	cellXfNumFmt  []int          // cellXfs index -> numFmtId
+	cellXfAlign   []Align        // cellXfs index -> horizontal alignment
+}
+
+// builtinDateNumFmtIDs are the built-in OOXML number formats that represent
+// dates or times (ECMA-376 Part 1, §18.8.30).
+var builtinDateNumFmtIDs = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	45: true, 46: true, 47: true,
+}
+
+// loadStyles parses xl/styles.xml, if present, into a Styles value. A missing
+// styles part (or one without cellXfs) is not an error - it simply means no
+// cell in the workbook can be a date.
+func loadStyles(zr *zip.Reader) (*Styles, error) {
+	s := &Styles{customNumFmts: map[int]string{}}
+
+	for _, f := range zr.File {
+		if f.Name != "xl/styles.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open styles.xml: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read styles.xml: %w", err)
+		}
+
+		var sx StylesXML
+		if err := xml.Unmarshal(data, &sx); err != nil {
+			return nil, fmt.Errorf("failed to parse styles.xml: %w", err)
+		}
+
+		for _, nf := range sx.NumFmts {
+			s.customNumFmts[nf.NumFmtID] = nf.FormatCode
+		}
+		s.cellXfNumFmt = make([]int, len(sx.CellXfs))
+		s.cellXfAlign = make([]Align, len(sx.CellXfs))
+		for i, xf := range sx.CellXfs {
+			s.cellXfNumFmt[i] = xf.NumFmtID
+			if xf.Alignment != nil {
+				s.cellXfAlign[i] = alignFromHorizontal(xf.Alignment.Horizontal)
+			}
+		}
+		break
+	}
+
+	return s, nil
+}
+
+// IsDateFormat reports whether the cellXfs entry at styleIdx (a Cell's "s"
+// attribute) formats its value as a date or time, whether through a built-in
+// numFmtId or a custom format code containing date/time tokens.
+func (s *Styles) IsDateFormat(styleIdx string) bool {
+	if s == nil || styleIdx == "" {
+		return false
+	}
+	idx, err := strconv.Atoi(styleIdx)
+	if err != nil || idx < 0 || idx >= len(s.cellXfNumFmt) {
+		return false
+	}
+
+	numFmtID := s.cellXfNumFmt[idx]
+	if builtinDateNumFmtIDs[numFmtID] {
+		return true
+	}
+	if code, ok := s.customNumFmts[numFmtID]; ok {
+		return looksLikeDateFormatCode(code)
+	}
+	return false
+}
+
+// Alignment reports the horizontal alignment the cellXfs entry at styleIdx
+// (a Cell's "s" attribute) was written with, or AlignDefault if styleIdx is
+// empty, out of range, or carries no <alignment> child.
+func (s *Styles) Alignment(styleIdx string) Align {
+	if s == nil || styleIdx == "" {
+		return AlignDefault
+	}
+	idx, err := strconv.Atoi(styleIdx)
+	if err != nil || idx < 0 || idx >= len(s.cellXfAlign) {
+		return AlignDefault
+	}
+	return s.cellXfAlign[idx]
+}
+
+// alignFromHorizontal maps an OOXML <alignment horizontal="..."/> value to an Align.
+func alignFromHorizontal(horizontal string) Align {
+	switch horizontal {
+	case "left":
+		return AlignLeft
+	case "center":
+		return AlignCenter
+	case "right":
+		return AlignRight
+	default:
+		return AlignDefault
+	}
+}
+
+// looksLikeDateFormatCode is a heuristic over a custom numFmt format code: if
+// it contains date/time tokens ("y", "m", "d", "h") outside of a quoted
+// literal, Excel is rendering the cell as a date or time.
+func looksLikeDateFormatCode(code string) bool {
+	inLiteral := false
+	for _, r := range code {
+		switch r {
+		case '"':
+			inLiteral = !inLiteral
+		case 'y', 'Y', 'd', 'D', 'h', 'H':
+			if !inLiteral {
+				return true
+			}
+		case 'm', 'M':
+			// "m"/"M" is also used for minutes in date formats, so treat it the
+			// same as the other date/time tokens - this code path specifically
+			// excludes AM/PM literals since those are quoted or matched above.
+			if !inLiteral {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Font models a single xl/styles.xml <font> entry.
+type Font struct {
+	Bold bool
+	Size float64
+	Name string
+}
+
+// Fill models a single <fill><patternFill .../></fill> entry.
+type Fill struct {
+	PatternType string // e.g. "none", "gray125"
+}
+
+// Border models a single <border> entry. xlmd never draws cell borders, so
+// this is currently just a marker for AddBorder's default, borderless entry.
+type Border struct{}
+
+// NumFmt is a custom <numFmt> entry; its ID is assigned by AddNumFmt and
+// always starts at 164, the first ID OOXML reserves for custom formats.
+type NumFmt struct {
+	ID         int
+	FormatCode string
+}
+
+// CellXf models a single <cellXfs><xf> entry: the font/fill/border/numFmt it
+// applies (by index into the StyleSheet's matching slices) and, optionally,
+// a horizontal alignment. A Cell's "s" attribute indexes into CellXfs.
+type CellXf struct {
+	FontID     int
+	FillID     int
+	BorderID   int
+	NumFmtID   int
+	Horizontal string // "left", "center", "right", or "" for no <alignment>
+}
+
+// StyleSheet is a programmatic model of xl/styles.xml's <numFmts>, <fonts>,
+// <fills>, <borders>, and <cellXfs> containers. Build one with
+// DefaultStyleSheet (or &StyleSheet{}, for a caller that wants to start from
+// nothing) and grow it with AddFont/AddFill/AddBorder/AddNumFmt/AddCellXf,
+// each of which returns the index the new entry was assigned - the same
+// index a Cell's "s" attribute (AddCellXf) or a CellXf's FontID/FillID/
+// BorderID/NumFmtID (the others) refers to it by. XML renders the finished
+// sheet to the <styleSheet> xlmd writes into xl/styles.xml.
+type StyleSheet struct {
+	NumFmts []NumFmt
+	Fonts   []Font
+	Fills   []Fill
+	Borders []Border
+	CellXfs []CellXf
+}
+
+// AddFont appends f and returns its fontId.
+func (s *StyleSheet) AddFont(f Font) int {
+	s.Fonts = append(s.Fonts, f)
+	return len(s.Fonts) - 1
+}
+
+// AddFill appends f and returns its fillId.
+func (s *StyleSheet) AddFill(f Fill) int {
+	s.Fills = append(s.Fills, f)
+	return len(s.Fills) - 1
+}
+
+// AddBorder appends b and returns its borderId.
+func (s *StyleSheet) AddBorder(b Border) int {
+	s.Borders = append(s.Borders, b)
+	return len(s.Borders) - 1
+}
+
+// AddNumFmt assigns code the next custom numFmtId (164, 165, ...) and
+// returns it.
+func (s *StyleSheet) AddNumFmt(code string) int {
+	id := 164 + len(s.NumFmts)
+	s.NumFmts = append(s.NumFmts, NumFmt{ID: id, FormatCode: code})
+	return id
+}
+
+// AddCellXf appends xf and returns its cellXfs index, i.e. the value a
+// Cell.StyleID ("s" attribute) must hold to use it.
+func (s *StyleSheet) AddCellXf(xf CellXf) int {
+	s.CellXfs = append(s.CellXfs, xf)
+	return len(s.CellXfs) - 1
+}
+
+// DefaultStyleSheet returns the StyleSheet WriteExcel/StreamWriter rely on by
+// default: a regular and a bold font, a blank and a "gray125" fill (the
+// second is required by the OOXML schema even when unused), a borderless
+// border, the built-in date numFmt (14, "m/d/yyyy"), and cellXfs at exactly
+// the indices the StyleDefault/StyleHeader/StyleDate/StyleAlignLeft/
+// StyleAlignCenter/StyleAlignRight constants name. Excel requires at least
+// this minimum to open a workbook without repair, even for one that adds no
+// styles of its own.
+func DefaultStyleSheet() *StyleSheet {
+	s := &StyleSheet{}
+
+	regular := s.AddFont(Font{Size: 11, Name: "Calibri"})
+	bold := s.AddFont(Font{Bold: true, Size: 11, Name: "Calibri"})
+	noFill := s.AddFill(Fill{PatternType: "none"})
+	_ = s.AddFill(Fill{PatternType: "gray125"})
+	border := s.AddBorder(Border{})
+
+	s.AddCellXf(CellXf{FontID: regular, FillID: noFill, BorderID: border})                       // StyleDefault
+	s.AddCellXf(CellXf{FontID: bold, FillID: noFill, BorderID: border})                          // StyleHeader
+	s.AddCellXf(CellXf{FontID: regular, FillID: noFill, BorderID: border, NumFmtID: 14})         // StyleDate
+	s.AddCellXf(CellXf{FontID: regular, FillID: noFill, BorderID: border, Horizontal: "left"})   // StyleAlignLeft
+	s.AddCellXf(CellXf{FontID: regular, FillID: noFill, BorderID: border, Horizontal: "center"}) // StyleAlignCenter
+	s.AddCellXf(CellXf{FontID: regular, FillID: noFill, BorderID: border, Horizontal: "right"})  // StyleAlignRight
+
+	return s
+}
+
+// XML renders s as the <styleSheet> document xlmd writes to xl/styles.xml.
+// Like the rest of this package's hand-rolled XML parts, it builds the
+// string directly rather than going through encoding/xml.
+func (s *StyleSheet) XML() string {
+	var sb strings.Builder
+	sb.WriteString(xmlDeclaration)
+	sb.WriteString(`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(s.NumFmts) > 0 {
+		fmt.Fprintf(&sb, `<numFmts count="%d">`, len(s.NumFmts))
+		for _, nf := range s.NumFmts {
+			fmt.Fprintf(&sb, `<numFmt numFmtId="%d" formatCode="%s"/>`, nf.ID, escapeXMLAttr(nf.FormatCode))
+		}
+		sb.WriteString(`</numFmts>`)
+	}
+
+	fmt.Fprintf(&sb, `<fonts count="%d">`, len(s.Fonts))
+	for _, f := range s.Fonts {
+		sb.WriteString(`<font>`)
+		if f.Bold {
+			sb.WriteString(`<b/>`)
+		}
+		fmt.Fprintf(&sb, `<sz val="%v"/><name val="%s"/>`, f.Size, escapeXMLAttr(f.Name))
+		sb.WriteString(`</font>`)
+	}
+	sb.WriteString(`</fonts>`)
+
+	fmt.Fprintf(&sb, `<fills count="%d">`, len(s.Fills))
+	for _, f := range s.Fills {
+		fmt.Fprintf(&sb, `<fill><patternFill patternType="%s"/></fill>`, escapeXMLAttr(f.PatternType))
+	}
+	sb.WriteString(`</fills>`)
+
+	fmt.Fprintf(&sb, `<borders count="%d">`, len(s.Borders))
+	for range s.Borders {
+		sb.WriteString(`<border><left/><right/><top/><bottom/><diagonal/></border>`)
+	}
+	sb.WriteString(`</borders>`)
+
+	sb.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+
+	fmt.Fprintf(&sb, `<cellXfs count="%d">`, len(s.CellXfs))
+	for _, xf := range s.CellXfs {
+		fmt.Fprintf(&sb, `<xf numFmtId="%d" fontId="%d" fillId="%d" borderId="%d" xfId="0"`, xf.NumFmtID, xf.FontID, xf.FillID, xf.BorderID)
+		if xf.FontID != 0 {
+			sb.WriteString(` applyFont="1"`)
+		}
+		if xf.NumFmtID != 0 {
+			sb.WriteString(` applyNumberFormat="1"`)
+		}
+		if xf.Horizontal != "" {
+			fmt.Fprintf(&sb, ` applyAlignment="1"><alignment horizontal="%s"/></xf>`, xf.Horizontal)
+		} else {
+			sb.WriteString(`/>`)
+		}
+	}
+	sb.WriteString(`</cellXfs>`)
+
+	sb.WriteString(`</styleSheet>`)
+	return sb.String()
+}
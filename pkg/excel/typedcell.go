@@ -0,0 +1,57 @@
+package excel
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// TypedCell is a cell's value paired with its resolved CellKind, the typed
+// counterpart to the plain-string cells in SheetData.Rows. ReadExcel and
+// ReadMarkdown both populate the parallel SheetData.Typed, and WriteExcel
+// uses it to write numbers, booleans, dates, and formulas as their native
+// OOXML cell types instead of forcing everything through the shared string
+// table.
+type TypedCell struct {
+	Value string
+	Kind  CellKind
+
+	// Hyperlink is the cell's external URL, if any (e.g. from a Markdown
+	// "[display](https://...)" link, or a decoded XLSX <hyperlink>). Empty
+	// means the cell carries no hyperlink.
+	Hyperlink string
+	// Tooltip is the hyperlink's optional hover text.
+	Tooltip string
+}
+
+// isoDateRe matches the ISO-8601 date/time forms formatExcelSerialDate
+// produces: "2006-01-02" or "2006-01-02T15:04:05".
+var isoDateRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(T\d{2}:\d{2}:\d{2})?$`)
+
+// decimalNumberRe restricts KindNumber classification to plain decimal
+// literals, matching the OOXML <v> grammar for numeric cells. strconv.
+// ParseFloat alone is too permissive for that purpose: it also accepts
+// "NaN", "Inf"/"+Inf"/"-Inf", and hex-float literals like "0x1p10", none of
+// which are legal inside a <v> element, so an ordinary data value such as
+// the literal text "NaN" must be rejected before it ever reaches ParseFloat.
+var decimalNumberRe = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// ClassifyValue infers a TypedCell's Kind from its plain-text value, the way
+// a Markdown cell - which carries no type information of its own beyond the
+// fenced-formula convention ReadMarkdown handles separately - must be
+// interpreted: "TRUE"/"FALSE" as Bool, a parseable number as Number, an
+// ISO-8601 date/time as Date, otherwise String.
+func ClassifyValue(raw string) TypedCell {
+	switch raw {
+	case "TRUE", "FALSE":
+		return TypedCell{Value: raw, Kind: KindBool}
+	}
+	if decimalNumberRe.MatchString(raw) {
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return TypedCell{Value: raw, Kind: KindNumber}
+		}
+	}
+	if isoDateRe.MatchString(raw) {
+		return TypedCell{Value: raw, Kind: KindDate}
+	}
+	return TypedCell{Value: raw, Kind: KindString}
+}
@@ -8,13 +8,142 @@ import "encoding/xml"
 type SheetData struct {
 	Name string
 	Rows [][]string // Data content: row index -> column index -> cell value
+
+	// ColumnAlign holds the GFM table alignment (left/center/right/default) for
+	// each column, indexed by column position. It is nil/shorter than the row
+	// width wherever no alignment was specified, which is read back as AlignDefault.
+	ColumnAlign []Align
+
+	// Merges holds the sheet's merged cell ranges (from <mergeCells>), e.g. "A1:B2".
+	Merges []MergeRange
+
+	// FreezePane is the top-left cell of the scrollable region (from
+	// <sheetViews><pane topLeftCell="..."/>), e.g. "A2" to freeze one header
+	// row. Empty means the sheet has no frozen pane.
+	FreezePane string
+
+	// ColWidths holds explicit column widths (from <cols><col width="..."/>),
+	// keyed by 0-based column index. A column with no entry uses Excel's
+	// default width.
+	ColWidths map[int]float64
+
+	// RowHeights holds explicit row heights (from <row ht="..."/>), keyed by
+	// 0-based row index. A row with no entry uses Excel's default height.
+	RowHeights map[int]float64
+
+	// AutoFilter is whether the sheet has a filter dropdown on its header row
+	// (from <autoFilter>).
+	AutoFilter bool
+
+	// Active is whether this is the sheet Excel should show as selected when
+	// the workbook is opened (from workbook.xml's <bookViews><workbookView
+	// activeTab="...">).
+	Active bool
+
+	// Typed carries each cell's resolved CellKind alongside Rows' plain
+	// display string, so WriteExcel can write numbers, booleans, dates, and
+	// formulas as their native OOXML types instead of shared strings. It is
+	// nil for callers that only fill in Rows; WriteExcel falls back to
+	// treating every cell as KindString in that case.
+	Typed [][]TypedCell
+}
+
+// MergeRange is a single merged cell range, e.g. Start "A1", End "B2".
+type MergeRange struct {
+	Start string
+	End   string
 }
 
+// Align is a column's GFM/Excel horizontal alignment.
+type Align int
+
+const (
+	AlignDefault Align = iota
+	AlignLeft
+	AlignCenter
+	AlignRight
+)
+
 // Worksheet represents the root element of an individual sheet's XML file (e.g., sheet1.xml).
 // This structure is used for marshalling and unmarshalling the entire worksheet content.
+// Field order mirrors the OOXML worksheet schema (sheetViews, cols, sheetData,
+// autoFilter, mergeCells, in that order) since it governs the element order xml.Marshal writes.
 type Worksheet struct {
-	XMLName   xml.Name     `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
-	SheetData SheetDataXML `xml:"sheetData"`
+	XMLName    xml.Name       `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
+	SheetViews *SheetViewsXML `xml:"sheetViews"`
+	Cols       *ColsXML       `xml:"cols"`
+	SheetData  SheetDataXML   `xml:"sheetData"`
+	AutoFilter *AutoFilterXML `xml:"autoFilter"`
+	MergeCells *MergeCellsXML `xml:"mergeCells"`
+	Hyperlinks *HyperlinksXML `xml:"hyperlinks"`
+}
+
+// SheetViewsXML represents the <sheetViews> container; xlmd only cares about
+// the first <sheetView>'s <pane>, used to detect/apply a frozen pane.
+type SheetViewsXML struct {
+	SheetView []SheetViewXML `xml:"sheetView"`
+}
+
+// SheetViewXML represents a single <sheetView>.
+type SheetViewXML struct {
+	Pane *PaneXML `xml:"pane"`
+}
+
+// PaneXML represents a <pane state="frozen" topLeftCell="..."/> element,
+// marking the first scrollable cell below/right of a frozen pane.
+type PaneXML struct {
+	XSplit      float64 `xml:"xSplit,attr,omitempty"`
+	YSplit      float64 `xml:"ySplit,attr,omitempty"`
+	TopLeftCell string  `xml:"topLeftCell,attr,omitempty"`
+	ActivePane  string  `xml:"activePane,attr,omitempty"`
+	State       string  `xml:"state,attr,omitempty"`
+}
+
+// AutoFilterXML represents the <autoFilter ref="A1:D10"/> element marking a
+// sheet's header row filter dropdowns active over ref.
+type AutoFilterXML struct {
+	Ref string `xml:"ref,attr"`
+}
+
+// ColsXML represents the <cols> container, listing explicit column widths.
+type ColsXML struct {
+	Col []ColXML `xml:"col"`
+}
+
+// ColXML represents a single <col min="..." max="..." width="..."/> entry.
+// min/max are 1-based and inclusive, and may span more than one column.
+type ColXML struct {
+	Min         int     `xml:"min,attr"`
+	Max         int     `xml:"max,attr"`
+	Width       float64 `xml:"width,attr"`
+	CustomWidth string  `xml:"customWidth,attr,omitempty"`
+}
+
+// MergeCellsXML represents the <mergeCells> container.
+type MergeCellsXML struct {
+	Count int            `xml:"count,attr,omitempty"`
+	Cell  []MergeCellXML `xml:"mergeCell"`
+}
+
+// MergeCellXML represents a single <mergeCell ref="A1:B2"/> entry.
+type MergeCellXML struct {
+	Ref string `xml:"ref,attr"`
+}
+
+// HyperlinksXML represents the <hyperlinks> container, listing each cell in
+// the sheet that carries an external hyperlink.
+type HyperlinksXML struct {
+	Hyperlink []HyperlinkXML `xml:"hyperlink"`
+}
+
+// HyperlinkXML represents a single <hyperlink ref="A1" r:id="rId1" tooltip="..."/>
+// entry; RID resolves, via the worksheet's own xl/worksheets/_rels/sheetN.xml.rels
+// part, to the external URL it points at.
+type HyperlinkXML struct {
+	Ref string `xml:"ref,attr"`
+	// RID is the relationship ID linking to the external URL (e.g., rId1).
+	RID     string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	Tooltip string `xml:"tooltip,attr,omitempty"`
 }
 
 // SheetDataXML represents the mandatory <sheetData> container within the Worksheet XML.
@@ -25,22 +154,78 @@ type SheetDataXML struct {
 
 // Row represents a single <row> element in the XML, defined by its 1-based index (R).
 type Row struct {
-	R     int    `xml:"r,attr"` // Row index (1-based, required for XLSX structure)
-	Cells []Cell `xml:"c"`
+	R            int     `xml:"r,attr"`                      // Row index (1-based, required for XLSX structure)
+	Ht           float64 `xml:"ht,attr,omitempty"`           // Explicit row height, in points
+	CustomHeight string  `xml:"customHeight,attr,omitempty"` // "1" when Ht is an explicit override
+	Cells        []Cell  `xml:"c"`
 }
 
 // Cell represents a single <c> element. This structure maps the technical XML attributes
 // necessary for cell formatting and referencing (Ref, Type, Val).
 type Cell struct {
-	Ref  string `xml:"r,attr"`           // e.g., "A1", "B5" - Required cell reference
-	Type string `xml:"t,attr,omitempty"` // "s" for shared string, otherwise numeric/empty
-	Val  string `xml:"v"`                // The cell's value or the index (if type="s")
+	Ref     string     `xml:"r,attr"`           // e.g., "A1", "B5" - Required cell reference
+	Type    string     `xml:"t,attr,omitempty"` // "s" shared string, "b" bool, "str" formula result, "inlineStr" inline text, otherwise numeric
+	StyleID string     `xml:"s,attr,omitempty"` // Index into styleSheet.cellXfs, used to detect date-formatted numbers and applied on write
+	Val     string     `xml:"v,omitempty"`      // The cell's raw value or the index (if type="s"); omitted for a formula cell with no cached value
+	Formula string     `xml:"f,omitempty"`      // Formula source, present when the cell holds a formula
+	Inline  *InlineStr `xml:"is"`               // Inline string payload, present when type="inlineStr"
+}
+
+// InlineStr represents the <is><t>...</t></is> inline string form, used instead of a
+// shared-string reference for cells that opt out of the SST.
+type InlineStr struct {
+	T string `xml:"t"`
 }
 
+// CellKind classifies the resolved, human-facing type of a cell's value.
+type CellKind int
+
+const (
+	KindString CellKind = iota
+	KindNumber
+	KindBool
+	KindDate
+	KindFormula
+)
+
+// CellValue is the resolved, typed representation of a Cell: its classification (Kind),
+// the raw XML value (Raw), and the string xlmd should actually display (Formatted).
+// For a KindDate cell, Formatted is the ISO-8601 date/time string (e.g.
+// "2024-01-15") formatExcelSerialDate produces - the same convention
+// TypedCell.Value uses for a date - and toCellXML converts it to an Excel
+// serial number via excelSerialFromISO when writing; Raw is unused for
+// KindDate.
+//
+// StyleID optionally pins a cell to a specific cellXfs index when writing
+// (see Sheet.SetCell); leave it at AutoStyle to let the writer pick a style
+// based on Kind and position (e.g. a bold style for header-row cells).
+type CellValue struct {
+	Kind      CellKind
+	Raw       string
+	Formatted string
+	StyleID   int
+}
+
+// AutoStyle is the sentinel CellValue.StyleID meaning "let the writer choose".
+const AutoStyle = -1
+
 // WorkbookXML represents the root element of the workbook.xml file, detailing the overall workbook structure.
 type WorkbookXML struct {
-	XMLName xml.Name  `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
-	Sheets  SheetsXML `xml:"sheets"`
+	XMLName   xml.Name      `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
+	BookViews *BookViewsXML `xml:"bookViews"`
+	Sheets    SheetsXML     `xml:"sheets"`
+}
+
+// BookViewsXML represents the <bookViews> container; xlmd only cares about
+// the first <workbookView>'s activeTab, used to detect/apply the workbook's
+// selected sheet.
+type BookViewsXML struct {
+	WorkbookView []WorkbookViewXML `xml:"workbookView"`
+}
+
+// WorkbookViewXML represents a single <workbookView activeTab="..."/> entry.
+type WorkbookViewXML struct {
+	ActiveTab int `xml:"activeTab,attr,omitempty"`
 }
 
 // SheetsXML represents the <sheets> container, listing all individual sheets in the workbook.
@@ -56,6 +241,20 @@ type SheetXMLInner struct {
 	RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
 }
 
+// RelationshipsXML represents the root <Relationships> element of a .rels
+// part (here, xl/_rels/workbook.xml.rels), mapping relationship IDs (as
+// referenced by SheetXMLInner.RID) to the package part they point at.
+type RelationshipsXML struct {
+	XMLName      xml.Name          `xml:"http://schemas.openxmlformats.org/package/2006/relationships Relationships"`
+	Relationship []RelationshipXML `xml:"Relationship"`
+}
+
+// RelationshipXML represents a single <Relationship Id="rId1" Target="worksheets/sheet1.xml"/> entry.
+type RelationshipXML struct {
+	ID     string `xml:"Id,attr"`
+	Target string `xml:"Target,attr"`
+}
+
 // SST (Shared String Table) represents the root element of sharedStrings.xml.
 // This table stores all unique strings/text used across the entire workbook.
 type SST struct {
@@ -0,0 +1,338 @@
+package excel
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Built-in cellXfs indices that Workbook.Save always makes available, mirroring
+// the style IDs a caller can pass via CellValue.StyleID.
+const (
+	StyleDefault     = 0 // general number format, regular weight
+	StyleHeader      = 1 // bold, for header-row cells
+	StyleDate        = 2 // built-in date numFmt (14, "m/d/yyyy")
+	StyleAlignLeft   = 3 // horizontal="left"
+	StyleAlignCenter = 4 // horizontal="center"
+	StyleAlignRight  = 5 // horizontal="right"
+)
+
+// alignStyleID maps a column's Align to one of the pre-built alignment
+// cellXfs, or AutoStyle if align is AlignDefault (meaning "no override").
+func alignStyleID(align Align) int {
+	switch align {
+	case AlignLeft:
+		return StyleAlignLeft
+	case AlignCenter:
+		return StyleAlignCenter
+	case AlignRight:
+		return StyleAlignRight
+	default:
+		return AutoStyle
+	}
+}
+
+// Workbook is a builder-style counterpart to WriteExcel: construct it with
+// NewWorkbook, add sheets with AddSheet, fill them in with Sheet.SetCell, then
+// call Save. Unlike WriteExcel it writes a real xl/styles.xml (default,
+// bold-header, and date cellXfs) instead of an empty stub, so callers can
+// preserve header emphasis and cell typing instead of stringifying everything.
+type Workbook struct {
+	sheets []*Sheet
+	active int
+}
+
+// Sheet is a single worksheet being built up via SetCell before Workbook.Save
+// writes the workbook out.
+type Sheet struct {
+	Name  string
+	cells map[cellCoord]CellValue
+
+	colAlign []Align
+	maxRow   int
+	maxCol   int
+
+	merges     []MergeRange
+	freezePane string
+	colWidths  map[int]float64
+}
+
+type cellCoord struct {
+	row, col int
+}
+
+// NewWorkbook returns an empty Workbook ready to receive sheets.
+func NewWorkbook() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet appends a new, empty sheet named name and returns it for filling in.
+func (wb *Workbook) AddSheet(name string) *Sheet {
+	s := &Sheet{Name: name, cells: make(map[cellCoord]CellValue)}
+	wb.sheets = append(wb.sheets, s)
+	return s
+}
+
+// SetActiveSheet marks the sheet at idx (0-based, in AddSheet order) as the
+// one Excel should show as selected when the workbook is opened.
+func (wb *Workbook) SetActiveSheet(idx int) {
+	wb.active = idx
+}
+
+// SetCell places v at the given 0-based row/col on the sheet. Set
+// v.StyleID to AutoStyle (the default zero value of a bare CellValue is 0,
+// i.e. StyleDefault - callers wanting header/date auto-styling should either
+// rely on Save's row-0 heuristic or set StyleID explicitly) to pick a style
+// by convention instead of by hand.
+func (s *Sheet) SetCell(row, col int, v CellValue) {
+	s.cells[cellCoord{row, col}] = v
+	if row > s.maxRow {
+		s.maxRow = row
+	}
+	if col > s.maxCol {
+		s.maxCol = col
+	}
+}
+
+// SetColumnAlign records the GFM/Excel horizontal alignment for col. Every
+// cell written in that column (including the header) is given a cellXf with
+// the matching <alignment horizontal="..."/>, overriding the usual
+// bold-header/date auto-style for that column.
+func (s *Sheet) SetColumnAlign(col int, align Align) {
+	growAlign(&s.colAlign, col)
+	s.colAlign[col] = align
+}
+
+// columnAlign returns the alignment set for col via SetColumnAlign, or
+// AlignDefault if none was set.
+func (s *Sheet) columnAlign(col int) Align {
+	if col < 0 || col >= len(s.colAlign) {
+		return AlignDefault
+	}
+	return s.colAlign[col]
+}
+
+// Save writes the workbook to filePath as a complete .xlsx archive, including
+// a real xl/styles.xml with a default, bold-header, and date cell format.
+func (wb *Workbook) Save(filePath string) error {
+	sharedStrings := make([]string, 0)
+	stringIndexMap := make(map[string]int)
+	for _, sheet := range wb.sheets {
+		for _, v := range sheet.cells {
+			if v.Kind != KindString {
+				continue
+			}
+			if _, exists := stringIndexMap[v.Formatted]; !exists {
+				stringIndexMap[v.Formatted] = len(sharedStrings)
+				sharedStrings = append(sharedStrings, v.Formatted)
+			}
+		}
+	}
+
+	zipFile, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create zip file: %w", err)
+	}
+	defer zipFile.Close()
+
+	zw := zip.NewWriter(zipFile)
+	defer zw.Close()
+
+	writeXML := func(filename string, data interface{}) error {
+		f, err := zw.Create(filename)
+		if err != nil {
+			return err
+		}
+		f.Write([]byte(xml.Header))
+		enc := xml.NewEncoder(f)
+		enc.Indent("", "  ")
+		if err := enc.Encode(data); err != nil {
+			return fmt.Errorf("failed to encode XML for %s: %w", filename, err)
+		}
+		return nil
+	}
+
+	contentTypeXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+	<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+	<Default Extension="xml" ContentType="application/xml"/>
+	<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+	<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+	<Override PartName="/xl/sharedStrings.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>
+`
+	for i := range wb.sheets {
+		contentTypeXML += fmt.Sprintf(`	<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+`, i+1)
+	}
+	contentTypeXML += `</Types>`
+	if f, err := zw.Create("[Content_Types].xml"); err != nil {
+		return err
+	} else {
+		f.Write([]byte(contentTypeXML))
+	}
+
+	relsXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	if f, err := zw.Create("_rels/.rels"); err != nil {
+		return err
+	} else {
+		f.Write([]byte(relsXML))
+	}
+
+	if f, err := zw.Create("xl/styles.xml"); err != nil {
+		return err
+	} else {
+		f.Write([]byte(minimalStylesXML()))
+	}
+
+	sstData := SST{
+		Count:       len(sharedStrings),
+		UniqueCount: len(sharedStrings),
+		SI:          make([]SI, len(sharedStrings)),
+	}
+	for i, str := range sharedStrings {
+		sstData.SI[i] = SI{T: str}
+	}
+	if err := writeXML("xl/sharedStrings.xml", sstData); err != nil {
+		return err
+	}
+
+	wbRelsXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+`
+	for i := range wb.sheets {
+		wbRelsXML += fmt.Sprintf(`	<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>
+`, i+1, i+1)
+	}
+	wbRelsXML += fmt.Sprintf(`	<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+`, len(wb.sheets)+1)
+	wbRelsXML += fmt.Sprintf(`	<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>
+</Relationships>`, len(wb.sheets)+2)
+	if f, err := zw.Create("xl/_rels/workbook.xml.rels"); err != nil {
+		return err
+	} else {
+		f.Write([]byte(wbRelsXML))
+	}
+
+	wbData := WorkbookXML{
+		Sheets: SheetsXML{Sheet: make([]SheetXMLInner, len(wb.sheets))},
+	}
+	for i, sheet := range wb.sheets {
+		name := sheet.Name
+		if name == "" {
+			name = fmt.Sprintf("Sheet%d", i+1)
+		}
+		wbData.Sheets.Sheet[i] = SheetXMLInner{
+			Name:    name,
+			SheetID: i + 1,
+			RID:     fmt.Sprintf("rId%d", i+1),
+		}
+	}
+	if err := writeXML("xl/workbook.xml", wbData); err != nil {
+		return err
+	}
+
+	for i, sheet := range wb.sheets {
+		wsData := Worksheet{
+			SheetViews: sheet.sheetViewsXML(),
+			Cols:       sheet.colsXML(),
+			SheetData:  SheetDataXML{Rows: sheet.toRows(stringIndexMap)},
+			MergeCells: sheet.mergeCellsXML(),
+		}
+		filename := fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)
+		if err := writeXML(filename, wsData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// toRows converts the sheet's sparse cell map into ordered XML Row records,
+// resolving each CellValue's OOXML cell type and style index.
+func (s *Sheet) toRows(stringIndexMap map[string]int) []Row {
+	byRow := make(map[int][]cellCoord)
+	for coord := range s.cells {
+		byRow[coord.row] = append(byRow[coord.row], coord)
+	}
+
+	rowNums := make([]int, 0, len(byRow))
+	for r := range byRow {
+		rowNums = append(rowNums, r)
+	}
+	sort.Ints(rowNums)
+
+	rows := make([]Row, 0, len(rowNums))
+	for _, r := range rowNums {
+		coords := byRow[r]
+		sort.Slice(coords, func(i, j int) bool { return coords[i].col < coords[j].col })
+
+		cells := make([]Cell, 0, len(coords))
+		for _, coord := range coords {
+			v := s.cells[coord]
+			cells = append(cells, v.toCellXML(coord, s.columnAlign(coord.col), stringIndexMap))
+		}
+		rows = append(rows, Row{R: r + 1, Cells: cells})
+	}
+	return rows
+}
+
+// toCellXML renders a CellValue as the <c> element xlmd would write for it,
+// choosing its OOXML type and resolving its style index: an explicit
+// CellValue.StyleID wins, then the column's alignment (applied to every cell
+// in the column, including the header), then the row/Kind-based defaults.
+func (v CellValue) toCellXML(coord cellCoord, colAlign Align, stringIndexMap map[string]int) Cell {
+	ref := fmt.Sprintf("%s%d", toColName(coord.col), coord.row+1)
+	styleID := v.StyleID
+	if styleID == AutoStyle {
+		styleID = alignStyleID(colAlign)
+	}
+	if styleID == AutoStyle {
+		switch {
+		case coord.row == 0:
+			styleID = StyleHeader
+		case v.Kind == KindDate:
+			styleID = StyleDate
+		default:
+			styleID = StyleDefault
+		}
+	}
+
+	c := Cell{Ref: ref, StyleID: strconv.Itoa(styleID)}
+	switch v.Kind {
+	case KindBool:
+		c.Type = "b"
+		c.Val = v.Raw
+	case KindFormula:
+		c.Formula = strings.TrimPrefix(v.Formatted, "=")
+	case KindDate:
+		serial, ok := excelSerialFromISO(v.Formatted)
+		if !ok {
+			serial = "0"
+		}
+		c.Val = serial
+	case KindNumber:
+		c.Val = v.Raw
+	default: // KindString
+		c.Type = "s"
+		c.Val = strconv.Itoa(stringIndexMap[v.Formatted])
+	}
+	return c
+}
+
+// minimalStylesXML returns the xl/styles.xml built from DefaultStyleSheet:
+// the cellXfs Workbook.Save and StreamWriter both rely on - StyleDefault
+// (general, regular), StyleHeader (bold), StyleDate (built-in numFmt 14,
+// "m/d/yyyy"), and StyleAlignLeft/Center/Right (a bare horizontal alignment,
+// applied to whichever columns a caller marked via Sheet.SetColumnAlign or
+// SheetStream.SetColumnAlign) - at exactly the cellXfs indices those
+// constants name.
+func minimalStylesXML() string {
+	return DefaultStyleSheet().XML()
+}
@@ -1,62 +1,5 @@
 package excel
 
-import (
-	"archive/zip"
-	"encoding/xml"
-	"fmt"
-	"os"
-	"strconv"
-)
-
-// Worksheet Main XML structure for a single sheet (e.g., sheet1.xml)
-type Worksheet struct {
-	XMLName   xml.Name     `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main worksheet"`
-	SheetData SheetDataXML `xml:"sheetData"`
-}
-
-type SheetDataXML struct {
-	Rows []RowXML `xml:"row"`
-}
-
-type RowXML struct {
-	R     int       `xml:"r,attr"` // Row index (1-based)
-	Cells []CellXML `xml:"c"`
-}
-
-type CellXML struct {
-	R string `xml:"r,attr"`           // Cell reference (e.g., "A1", "B5")
-	T string `xml:"t,attr,omitempty"` // Type: "s" for shared string
-	V string `xml:"v"`                // Value: string index if t="s", raw value otherwise
-}
-
-// SST (Shared String Table): Unique strings used in the workbook.
-type SST struct {
-	XMLName     xml.Name `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main sst"`
-	Count       int      `xml:"count,attr"`
-	UniqueCount int      `xml:"uniqueCount,attr"`
-	SI          []SI     `xml:"si"`
-}
-
-type SI struct {
-	T string `xml:"t"` // Text
-}
-
-// WorkbookXML lists all sheets and their relationships.
-type WorkbookXML struct {
-	XMLName xml.Name  `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main workbook"`
-	Sheets  SheetsXML `xml:"sheets"`
-}
-
-type SheetsXML struct {
-	Sheet []SheetXMLInner `xml:"sheet"`
-}
-
-type SheetXMLInner struct {
-	Name    string `xml:"name,attr"`
-	SheetID int    `xml:"sheetId,attr"`
-	RID     string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
-}
-
 // toColName converts a 0-based column index (0, 1, 2...) into an Excel column letter ("A", "B", "C"...).
 func toColName(col int) string {
 	if col < 0 {
@@ -64,203 +7,112 @@ func toColName(col int) string {
 	}
 	var name string
 	for col >= 0 {
-		name = string('A'+col%26) + name
+		name = string(rune('A'+col%26)) + name
 		col = col/26 - 1
 	}
 	return name
 }
 
-// WriteExcel writes given SheetData into an Excel file using only standard library zip/xml.
+// WriteOptions controls the workbook-level niceties WriteExcelWithOptions
+// applies on top of each sheet's own data/layout.
+type WriteOptions struct {
+	// FreezeHeader freezes each sheet's first row (equivalent to its
+	// SheetData.FreezePane being "A2"), unless the sheet already sets its own
+	// FreezePane.
+	FreezeHeader bool
+
+	// AutoFilter turns on each sheet's header-row filter dropdowns, unless
+	// the sheet already sets its own SheetData.AutoFilter.
+	AutoFilter bool
+
+	// ActiveSheetIndex is the 0-based index, in append order, of the sheet
+	// Excel should show as selected when the workbook is opened.
+	ActiveSheetIndex int
+}
+
+// DefaultWriteOptions returns the options WriteExcel uses: a frozen header
+// row and auto-filter on every sheet, first sheet active - equivalent to
+// excelize's SetPanes/AutoFilter defaults for a Markdown table, which always
+// has a header row by GFM rules.
+func DefaultWriteOptions() WriteOptions {
+	return WriteOptions{FreezeHeader: true, AutoFilter: true}
+}
+
+// WriteExcel writes the given SheetData into an Excel file, using
+// DefaultWriteOptions. It is a shim over WriteExcelWithOptions for callers
+// that don't need to tweak the header freeze/auto-filter/active-sheet
+// defaults.
 func WriteExcel(filePath string, sheets []SheetData) error {
-	sharedStrings := make([]string, 0)
-	stringIndexMap := make(map[string]int)
+	return WriteExcelWithOptions(filePath, sheets, DefaultWriteOptions())
+}
 
-	for _, sheet := range sheets {
-		for _, row := range sheet.Rows {
-			for _, cellValue := range row {
-				// Only process non-empty strings
-				if cellValue != "" {
-					if _, exists := stringIndexMap[cellValue]; !exists {
-						stringIndexMap[cellValue] = len(sharedStrings)
-						sharedStrings = append(sharedStrings, cellValue)
-					}
-				}
-			}
-		}
-	}
-	zipFile, err := os.Create(filePath)
+// WriteExcelWithOptions writes the given SheetData into an Excel file. It is
+// a thin wrapper over StreamWriter: cells are written via their resolved
+// SheetData.Typed kind where available (numbers, booleans, dates, and
+// formulas as their native OOXML types, not shared strings), falling back to
+// plain strings for callers that only filled in Rows. Column alignment
+// (SheetData.ColumnAlign), merged cells, frozen panes, column/row sizes, and
+// auto-filters are carried through so a Markdown -> XLSX -> Markdown
+// round-trip doesn't lose them - without buffering every sheet's cells in
+// memory the way the Workbook builder does. opts' FreezeHeader/AutoFilter
+// only apply to a sheet that doesn't already set its own FreezePane/
+// AutoFilter, and only when the sheet has rows to freeze/filter over. sw's
+// output file and shared-string spill file are released via sw.Close, even
+// on a mid-loop write error, by deferring the close instead of only calling
+// it on the success path.
+func WriteExcelWithOptions(filePath string, sheets []SheetData, opts WriteOptions) (err error) {
+	sw, err := NewStreamWriter(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to create zip file: %w", err)
+		return err
 	}
-	defer zipFile.Close()
+	defer func() {
+		if cerr := sw.Close(); err == nil {
+			err = cerr
+		}
+	}()
 
-	zw := zip.NewWriter(zipFile)
-	defer zw.Close()
+	sw.SetActiveSheetIndex(opts.ActiveSheetIndex)
 
-	// Helper to write XML files into the zip archive
-	writeXML := func(filename string, data interface{}) error {
-		f, err := zw.Create(filename)
+	for _, sheetData := range sheets {
+		var ss *SheetStream
+		ss, err = sw.AppendSheet(sheetData.Name)
 		if err != nil {
 			return err
 		}
-
-		// Write XML header manually, as xml.Encoder doesn't handle namespaces well in the header
-		f.Write([]byte(xml.Header))
-
-		enc := xml.NewEncoder(f)
-		enc.Indent("", "  ")
-		if err := enc.Encode(data); err != nil {
-			return fmt.Errorf("failed to encode XML for %s: %w", filename, err)
+		for col, align := range sheetData.ColumnAlign {
+			ss.SetColumnAlign(col, align)
 		}
-		return nil
-	}
-
-	// [Content_Types].xml (Defines MIME types for all parts)
-	contentTypeXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
-	<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
-	<Default Extension="xml" ContentType="application/xml"/>
-	<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
-	<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
-	<Override PartName="/xl/sharedStrings.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"/>
-`
-	for i := range sheets {
-		contentTypeXML += fmt.Sprintf(`	<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
-`, i+1)
-	}
-	contentTypeXML += `</Types>`
-	if f, err := zw.Create("[Content_Types].xml"); err != nil {
-		return err
-	} else {
-		f.Write([]byte(contentTypeXML))
-	}
-
-	// _rels/.rels (Package Relationships)
-	relsXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
-	<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
-</Relationships>`
-	if f, err := zw.Create("_rels/.rels"); err != nil {
-		return err
-	} else {
-		f.Write([]byte(relsXML))
-	}
-
-	// xl/styles.xml (Required empty styles file)
-	stylesXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"></styleSheet>`
-	if f, err := zw.Create("xl/styles.xml"); err != nil {
-		return err
-	} else {
-		f.Write([]byte(stylesXML))
-	}
-
-	sstData := SST{
-		Count:       len(sharedStrings),
-		UniqueCount: len(sharedStrings),
-		SI:          make([]SI, len(sharedStrings)),
-	}
-	for i, s := range sharedStrings {
-		sstData.SI[i] = SI{T: s}
-	}
-
-	if err := writeXML("xl/sharedStrings.xml", sstData); err != nil {
-		return err
-	}
-
-	// xl/_rels/workbook.xml.rels (Workbook Relationships)
-	wbRelsXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
-`
-	for i := range sheets {
-		wbRelsXML += fmt.Sprintf(`	<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>
-`, i+1, i+1)
-	}
-	// rIdX+1 for styles, rIdX+2 for shared strings
-	wbRelsXML += fmt.Sprintf(`	<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
-`, len(sheets)+1)
-	wbRelsXML += fmt.Sprintf(`	<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>
-</Relationships>`, len(sheets)+2)
-
-	if f, err := zw.Create("xl/_rels/workbook.xml.rels"); err != nil {
-		return err
-	} else {
-		f.Write([]byte(wbRelsXML))
-	}
-
-	wbData := WorkbookXML{
-		Sheets: SheetsXML{
-			Sheet: make([]SheetXMLInner, len(sheets)),
-		},
-	}
-	for i, sheet := range sheets {
-		sheetName := sheet.Name
-		if sheetName == "" {
-			sheetName = fmt.Sprintf("Sheet%d", i+1)
+		for _, merge := range sheetData.Merges {
+			ss.SetMerge(merge.Start, merge.End)
 		}
-		wbData.Sheets.Sheet[i] = SheetXMLInner{
-			Name:    sheetName,
-			SheetID: i + 1,
-			RID:     fmt.Sprintf("rId%d", i+1),
+		switch {
+		case sheetData.FreezePane != "":
+			ss.SetFreezePane(sheetData.FreezePane)
+		case opts.FreezeHeader && len(sheetData.Rows) > 0:
+			ss.SetFreezePane("A2")
+		}
+		for col, width := range sheetData.ColWidths {
+			ss.SetColWidth(col, width)
+		}
+		for row, height := range sheetData.RowHeights {
+			ss.SetRowHeight(row, height)
+		}
+		if (sheetData.AutoFilter || opts.AutoFilter) && len(sheetData.Rows) > 0 {
+			ss.SetAutoFilter(true)
 		}
-	}
-	if err := writeXML("xl/workbook.xml", wbData); err != nil {
-		return err
-	}
-
-	for i, sheet := range sheets {
-		xmlRows := make([]RowXML, 0, len(sheet.Rows))
-
-		for rIdx, row := range sheet.Rows {
-			rowNum := rIdx + 1 // 1-based index
-			xmlCells := make([]CellXML, 0, len(row))
 
-			// Find the column count for this row (max index)
-			maxColIndex := -1
-			for j, val := range row {
-				if val != "" {
-					maxColIndex = j
+		if len(sheetData.Typed) > 0 {
+			for _, row := range sheetData.Typed {
+				if err = ss.WriteTypedRow(row); err != nil {
+					return err
 				}
 			}
-
-			// Only write cells up to the last non-empty column
-			for cIdx := 0; cIdx <= maxColIndex; cIdx++ {
-				cellValue := row[cIdx]
-				if cellValue == "" {
-					continue // Excel omits empty cells in the XML
+		} else {
+			for _, row := range sheetData.Rows {
+				if err = ss.WriteRow(row); err != nil {
+					return err
 				}
-
-				colName := toColName(cIdx)
-				cellRef := fmt.Sprintf("%s%d", colName, rowNum)
-
-				// All strings are stored as shared strings
-				stringIndex := stringIndexMap[cellValue]
-
-				xmlCells = append(xmlCells, CellXML{
-					R: cellRef,
-					T: "s", // Shared String type
-					V: strconv.Itoa(stringIndex),
-				})
 			}
-
-			// Only include rows that have at least one cell
-			if len(xmlCells) > 0 {
-				xmlRows = append(xmlRows, RowXML{
-					R:     rowNum,
-					Cells: xmlCells,
-				})
-			}
-		}
-
-		wsData := Worksheet{
-			SheetData: SheetDataXML{
-				Rows: xmlRows,
-			},
-		}
-
-		filename := fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)
-		if err := writeXML(filename, wsData); err != nil {
-			return err
 		}
 	}
 
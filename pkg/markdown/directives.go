@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"xlmd/pkg/excel"
+)
+
+// parseDirectives consumes any leading "<!-- xlmd:... -->" lines (skipping
+// blank lines between them) from content, decoding the layout GFM has no
+// native syntax for - merged ranges, a frozen pane, and explicit column
+// widths/row heights - and returns them alongside the remaining, un-consumed
+// content.
+func parseDirectives(content string) (merges []excel.MergeRange, freezePane string, colWidths map[int]float64, rowHeights map[int]float64, rest string) {
+	lines := strings.Split(content, "\n")
+
+	idx := 0
+	for idx < len(lines) {
+		line := strings.TrimSpace(lines[idx])
+		if line == "" {
+			idx++
+			continue
+		}
+		if !strings.HasPrefix(line, "<!-- xlmd:") || !strings.HasSuffix(line, "-->") {
+			break
+		}
+		directive := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "<!--"), "-->"))
+		applyDirective(directive, &merges, &freezePane, &colWidths, &rowHeights)
+		idx++
+	}
+
+	return merges, freezePane, colWidths, rowHeights, strings.Join(lines[idx:], "\n")
+}
+
+// applyDirective decodes a single directive body (e.g. "xlmd:merge A1:B2",
+// without the surrounding "<!--"/"-->") into the appropriate out parameter.
+// An unrecognized or malformed directive is silently ignored, the same way
+// parseTable ignores lines it doesn't understand.
+func applyDirective(directive string, merges *[]excel.MergeRange, freezePane *string, colWidths *map[int]float64, rowHeights *map[int]float64) {
+	switch {
+	case strings.HasPrefix(directive, "xlmd:merge "):
+		ref := strings.TrimSpace(strings.TrimPrefix(directive, "xlmd:merge "))
+		start, end, ok := strings.Cut(ref, ":")
+		if ok {
+			*merges = append(*merges, excel.MergeRange{Start: start, End: end})
+		}
+
+	case strings.HasPrefix(directive, "xlmd:freeze "):
+		*freezePane = strings.TrimSpace(strings.TrimPrefix(directive, "xlmd:freeze "))
+
+	case strings.HasPrefix(directive, "xlmd:colwidth "):
+		spec := strings.TrimSpace(strings.TrimPrefix(directive, "xlmd:colwidth "))
+		colStr, widthStr, ok := strings.Cut(spec, "=")
+		if !ok {
+			return
+		}
+		col, colErr := strconv.Atoi(strings.TrimSpace(colStr))
+		width, widthErr := strconv.ParseFloat(strings.TrimSpace(widthStr), 64)
+		if colErr != nil || widthErr != nil {
+			return
+		}
+		if *colWidths == nil {
+			*colWidths = make(map[int]float64)
+		}
+		(*colWidths)[col] = width
+
+	case strings.HasPrefix(directive, "xlmd:rowheight "):
+		spec := strings.TrimSpace(strings.TrimPrefix(directive, "xlmd:rowheight "))
+		rowStr, heightStr, ok := strings.Cut(spec, "=")
+		if !ok {
+			return
+		}
+		row, rowErr := strconv.Atoi(strings.TrimSpace(rowStr))
+		height, heightErr := strconv.ParseFloat(strings.TrimSpace(heightStr), 64)
+		if rowErr != nil || heightErr != nil {
+			return
+		}
+		if *rowHeights == nil {
+			*rowHeights = make(map[int]float64)
+		}
+		(*rowHeights)[row] = height
+	}
+}
+
+// writeDirectives emits the "<!-- xlmd:... -->" lines encoding sheet's
+// merges, frozen pane, column widths, and row heights, in that order, or
+// nothing if it has none.
+func writeDirectives(sb *strings.Builder, sheet excel.SheetData) {
+	for _, m := range sheet.Merges {
+		sb.WriteString("<!-- xlmd:merge " + m.Start + ":" + m.End + " -->\n")
+	}
+
+	if sheet.FreezePane != "" {
+		sb.WriteString("<!-- xlmd:freeze " + sheet.FreezePane + " -->\n")
+	}
+
+	if len(sheet.ColWidths) > 0 {
+		cols := make([]int, 0, len(sheet.ColWidths))
+		for col := range sheet.ColWidths {
+			cols = append(cols, col)
+		}
+		sort.Ints(cols)
+		for _, col := range cols {
+			sb.WriteString("<!-- xlmd:colwidth " + strconv.Itoa(col) + "=" + strconv.FormatFloat(sheet.ColWidths[col], 'g', -1, 64) + " -->\n")
+		}
+	}
+
+	if len(sheet.RowHeights) > 0 {
+		rows := make([]int, 0, len(sheet.RowHeights))
+		for row := range sheet.RowHeights {
+			rows = append(rows, row)
+		}
+		sort.Ints(rows)
+		for _, row := range rows {
+			sb.WriteString("<!-- xlmd:rowheight " + strconv.Itoa(row) + "=" + strconv.FormatFloat(sheet.RowHeights[row], 'g', -1, 64) + " -->\n")
+		}
+	}
+}
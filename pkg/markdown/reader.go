@@ -32,14 +32,21 @@ func ReadMarkdown(filePath string) ([]excel.SheetData, error) {
 
 	if len(matches) == 0 {
 		// If no '## Sheet Name' headings are found, treat the entire file as a single default sheet
-		tableData, err := parseTable(string(content))
+		merges, freezePane, colWidths, rowHeights, tableContent := parseDirectives(string(content))
+		tableData, colAlign, typed, err := parseTable(tableContent)
 		if err != nil {
 			return nil, err
 		}
 		if len(tableData) > 0 {
 			sheets = append(sheets, excel.SheetData{
-				Name: "Sheet1", // Default name for un-named sheets
-				Rows: tableData,
+				Name:        "Sheet1", // Default name for un-named sheets
+				Rows:        tableData,
+				ColumnAlign: colAlign,
+				Merges:      merges,
+				FreezePane:  freezePane,
+				ColWidths:   colWidths,
+				RowHeights:  rowHeights,
+				Typed:       typed,
 			})
 		}
 		return sheets, nil
@@ -55,7 +62,8 @@ func ReadMarkdown(filePath string) ([]excel.SheetData, error) {
 			continue
 		}
 
-		tableData, err := parseTable(sheetContent)
+		merges, freezePane, colWidths, rowHeights, tableContent := parseDirectives(sheetContent)
+		tableData, colAlign, typed, err := parseTable(tableContent)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing table for sheet %s: %w", sheetName, err)
 		}
@@ -66,8 +74,14 @@ func ReadMarkdown(filePath string) ([]excel.SheetData, error) {
 			sheetName = strings.ReplaceAll(sheetName, "/", "-")
 
 			sheets = append(sheets, excel.SheetData{
-				Name: sheetName,
-				Rows: tableData,
+				Name:        sheetName,
+				Rows:        tableData,
+				ColumnAlign: colAlign,
+				Merges:      merges,
+				FreezePane:  freezePane,
+				ColWidths:   colWidths,
+				RowHeights:  rowHeights,
+				Typed:       typed,
 			})
 		}
 	}
@@ -75,10 +89,16 @@ func ReadMarkdown(filePath string) ([]excel.SheetData, error) {
 	return sheets, nil
 }
 
-// parseTable takes a string containing a Markdown table and extracts the rows and cells.
-func parseTable(mdContent string) ([][]string, error) {
+// parseTable takes a string containing a Markdown table and extracts the
+// rows and cells, the per-column alignment declared by the separator row's
+// ":---", ":---:", "---:" markers (AlignDefault for plain "---"), and each
+// cell's resolved TypedCell (a fenced “ `=...` “ cell as KindFormula,
+// otherwise classified by excel.ClassifyValue).
+func parseTable(mdContent string) ([][]string, []excel.Align, [][]excel.TypedCell, error) {
 	lines := strings.Split(mdContent, "\n")
 	rows := [][]string{}
+	var typedRows [][]excel.TypedCell
+	var colAlign []excel.Align
 
 	expectedCols := 0
 
@@ -114,13 +134,22 @@ func parseTable(mdContent string) ([][]string, error) {
 			}
 
 			if isSeparator {
+				if colAlign == nil {
+					colAlign = make([]excel.Align, len(rawCells))
+					for i, cell := range rawCells {
+						colAlign[i] = parseAlignMarker(strings.TrimSpace(cell))
+					}
+				}
 				continue // Skip the separator row
 			}
 
 			// If we are here, it's a data row (header or content)
 			rowVals := make([]string, 0, len(rawCells))
+			typedVals := make([]excel.TypedCell, 0, len(rawCells))
 			for _, cell := range rawCells {
-				rowVals = append(rowVals, strings.TrimSpace(cell))
+				cell = strings.TrimSpace(cell)
+				rowVals = append(rowVals, cell)
+				typedVals = append(typedVals, parseTypedCell(cell))
 			}
 
 			if expectedCols == 0 && len(rowVals) > 0 {
@@ -134,14 +163,17 @@ func parseTable(mdContent string) ([][]string, error) {
 					// Pad with empty strings
 					for i := len(rowVals); i < expectedCols; i++ {
 						rowVals = append(rowVals, "")
+						typedVals = append(typedVals, excel.TypedCell{Kind: excel.KindString})
 					}
 				} else if len(rowVals) > expectedCols {
 					// Truncate
 					rowVals = rowVals[:expectedCols]
+					typedVals = typedVals[:expectedCols]
 				}
 			}
 
 			rows = append(rows, rowVals)
+			typedRows = append(typedRows, typedVals)
 		} else {
 			// Once we hit a non-table line, we stop parsing the table
 			if len(rows) > 0 {
@@ -150,5 +182,56 @@ func parseTable(mdContent string) ([][]string, error) {
 		}
 	}
 
-	return rows, nil
+	return rows, colAlign, typedRows, nil
+}
+
+// linkCellRe matches a table cell consisting of a single Markdown link, e.g.
+// "[display](https://example.com)" or "[display](https://example.com \"tip\")".
+var linkCellRe = regexp.MustCompile(`^\[([^\]]*)\]\(([^)\s]+)(?:\s+"([^"]*)")?\)$`)
+
+// parseTypedCell classifies a single already-trimmed table cell into a
+// TypedCell: a fenced formula cell (e.g. "`=SUM(A1:A3)`") becomes
+// KindFormula with its "=..." source unwrapped, a Markdown link
+// ("[display](url)") becomes a KindString cell carrying Hyperlink (and
+// Tooltip, from a link title), otherwise the cell is classified by
+// excel.ClassifyValue.
+func parseTypedCell(cell string) excel.TypedCell {
+	if formula, ok := parseFormulaCell(cell); ok {
+		return excel.TypedCell{Value: formula, Kind: excel.KindFormula}
+	}
+	if m := linkCellRe.FindStringSubmatch(cell); m != nil {
+		return excel.TypedCell{Value: m[1], Kind: excel.KindString, Hyperlink: m[2], Tooltip: m[3]}
+	}
+	return excel.ClassifyValue(cell)
+}
+
+// parseFormulaCell unwraps xlmd's fenced-formula convention - a cell
+// consisting of a single code span whose content starts with "=" - into the
+// bare formula source, reporting false for any other cell.
+func parseFormulaCell(cell string) (string, bool) {
+	if len(cell) < 2 || !strings.HasPrefix(cell, "`") || !strings.HasSuffix(cell, "`") {
+		return "", false
+	}
+	inner := cell[1 : len(cell)-1]
+	if !strings.HasPrefix(inner, "=") {
+		return "", false
+	}
+	return inner, true
+}
+
+// parseAlignMarker reads a single GFM separator-row cell (e.g. ":---:") and
+// returns the alignment it declares.
+func parseAlignMarker(cell string) excel.Align {
+	left := strings.HasPrefix(cell, ":")
+	right := strings.HasSuffix(cell, ":")
+	switch {
+	case left && right:
+		return excel.AlignCenter
+	case left:
+		return excel.AlignLeft
+	case right:
+		return excel.AlignRight
+	default:
+		return excel.AlignDefault
+	}
 }
@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"fmt"
 	"strings"
 	"xlmd/pkg/excel"
 )
@@ -18,6 +19,10 @@ func ToMarkdown(sheets []excel.SheetData) string {
 		// section heading
 		sb.WriteString("## " + sheet.Name + "\n\n")
 
+		// layout extensions GFM has no native syntax for (merges, frozen
+		// pane, column widths), encoded as HTML comments before the table
+		writeDirectives(&sb, sheet)
+
 		// determine column count (based on longest row)
 		colCount := 0
 		for _, r := range sheet.Rows {
@@ -39,15 +44,22 @@ func ToMarkdown(sheets []excel.SheetData) string {
 		}
 
 		// first row as header
-		header := sheet.Rows[0]
-		sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+		sb.WriteString("| " + strings.Join(renderRow(sheet, 0), " | ") + " |\n")
 
-		// separator row
-		sb.WriteString("|" + strings.Repeat(" --- |", colCount) + "\n")
+		// separator row, honoring any per-column alignment captured from Excel
+		sb.WriteString("|")
+		for col := 0; col < colCount; col++ {
+			align := excel.AlignDefault
+			if col < len(sheet.ColumnAlign) {
+				align = sheet.ColumnAlign[col]
+			}
+			sb.WriteString(" " + alignMarker(align) + " |")
+		}
+		sb.WriteString("\n")
 
 		// remaining rows
-		for _, row := range sheet.Rows[1:] {
-			sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		for i := 1; i < len(sheet.Rows); i++ {
+			sb.WriteString("| " + strings.Join(renderRow(sheet, i), " | ") + " |\n")
 		}
 
 		sb.WriteString("\n")
@@ -55,3 +67,56 @@ func ToMarkdown(sheets []excel.SheetData) string {
 
 	return sb.String()
 }
+
+// renderRow returns sheet.Rows[rowIdx]'s display cells, substituting a
+// fenced “ `=...` “ code span for any cell sheet.Typed marks as KindFormula,
+// and a "[display](url)" link for any cell carrying a Hyperlink - numbers,
+// booleans, and dates are already plain, unquoted text in Rows, so only
+// formulas and hyperlinks need special rendering.
+func renderRow(sheet excel.SheetData, rowIdx int) []string {
+	row := sheet.Rows[rowIdx]
+	var typedRow []excel.TypedCell
+	if rowIdx < len(sheet.Typed) {
+		typedRow = sheet.Typed[rowIdx]
+	}
+
+	cells := make([]string, len(row))
+	for col, val := range row {
+		if col < len(typedRow) {
+			cell := typedRow[col]
+			switch {
+			case cell.Kind == excel.KindFormula:
+				cells[col] = "`" + cell.Value + "`"
+				continue
+			case cell.Hyperlink != "":
+				cells[col] = renderLink(cell)
+				continue
+			}
+		}
+		cells[col] = val
+	}
+	return cells
+}
+
+// renderLink formats cell as a Markdown link, appending its Tooltip as a
+// link title when present.
+func renderLink(cell excel.TypedCell) string {
+	if cell.Tooltip != "" {
+		return fmt.Sprintf("[%s](%s \"%s\")", cell.Value, cell.Hyperlink, cell.Tooltip)
+	}
+	return fmt.Sprintf("[%s](%s)", cell.Value, cell.Hyperlink)
+}
+
+// alignMarker renders the GFM separator-row marker for a column's alignment.
+func alignMarker(align excel.Align) string {
+	switch align {
+	case excel.AlignLeft:
+		return ":---"
+	case excel.AlignCenter:
+		return ":---:"
+	case excel.AlignRight:
+		return "---:"
+	default:
+		return "---"
+	}
+}
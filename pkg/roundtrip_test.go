@@ -0,0 +1,505 @@
+package pkg_test
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"xlmd/pkg/excel"
+	"xlmd/pkg/markdown"
+)
+
+// readZipEntry returns the contents of name within the zip archive at path,
+// for tests that need to inspect the raw XML a writer produced.
+func readZipEntry(path, name string) (string, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", os.ErrNotExist
+}
+
+// TestStreamExcelRoundTrip verifies that StreamExcel (the SAX-based reader)
+// surfaces the same rows ReadExcel does, one callback per <row>, for a file
+// written by the normal WriteExcel path.
+func TestStreamExcelRoundTrip(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "stream.xlsx")
+	sheets := []excel.SheetData{{
+		Name: "Sheet1",
+		Rows: [][]string{{"Name", "Age"}, {"Ada", "36"}, {"Grace", "85"}},
+	}}
+	if err := excel.WriteExcel(out, sheets); err != nil {
+		t.Fatalf("WriteExcel failed: %v", err)
+	}
+
+	var got [][]string
+	err := excel.StreamExcel(out, func(sheet string, rowIdx int, cells []string) error {
+		got = append(got, cells)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamExcel failed: %v", err)
+	}
+	if len(got) != len(sheets[0].Rows) {
+		t.Fatalf("row count mismatch: got %d, want %d", len(got), len(sheets[0].Rows))
+	}
+	for i, row := range sheets[0].Rows {
+		if len(got[i]) != len(row) || got[i][0] != row[0] || got[i][1] != row[1] {
+			t.Errorf("row %d mismatch: got %v, want %v", i, got[i], row)
+		}
+	}
+}
+
+// TestTypedCellRoundTrip verifies that numbers, booleans, and dates survive a
+// Markdown -> XLSX -> read-back round trip as their native OOXML cell types,
+// not shared strings.
+func TestTypedCellRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "in.md")
+	xlsxPath := filepath.Join(dir, "out.xlsx")
+
+	md := "## Sheet1\n\n" +
+		"| Qty | InStock | Shipped |\n" +
+		"| --- | --- | --- |\n" +
+		"| 42 | TRUE | 2024-01-15 |\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("failed to write markdown fixture: %v", err)
+	}
+
+	sheets, err := markdown.ReadMarkdown(mdPath)
+	if err != nil {
+		t.Fatalf("ReadMarkdown failed: %v", err)
+	}
+	if err := excel.WriteExcel(xlsxPath, sheets); err != nil {
+		t.Fatalf("WriteExcel failed: %v", err)
+	}
+
+	got, err := excel.ReadExcel(xlsxPath)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	if len(got) != 1 || len(got[0].Typed) != 2 {
+		t.Fatalf("unexpected sheet/row shape: %+v", got)
+	}
+	dataRow := got[0].Typed[1]
+	wantKinds := []excel.CellKind{excel.KindNumber, excel.KindBool, excel.KindDate}
+	for col, want := range wantKinds {
+		if dataRow[col].Kind != want {
+			t.Errorf("col %d: got Kind %v, want %v", col, dataRow[col].Kind, want)
+		}
+	}
+}
+
+// TestWorkbookBuilderFormulaRoundTrip verifies that the Workbook/Sheet
+// builder API writes a KindFormula cell as a real <f> element rather than
+// dumping its "=..." source into <v>, and that the formula source survives a
+// read-back.
+func TestWorkbookBuilderFormulaRoundTrip(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "workbook.xlsx")
+
+	wb := excel.NewWorkbook()
+	sheet := wb.AddSheet("Sheet1")
+	sheet.SetCell(0, 0, excel.CellValue{Kind: excel.KindString, Formatted: "Total"})
+	sheet.SetCell(1, 0, excel.CellValue{Kind: excel.KindFormula, Formatted: "=SUM(A1:A3)"})
+
+	if err := wb.Save(out); err != nil {
+		t.Fatalf("Workbook.Save failed: %v", err)
+	}
+
+	sheetXML, err := readZipEntry(out, "xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("failed to read worksheet part: %v", err)
+	}
+	if strings.Contains(sheetXML, "=SUM") && !strings.Contains(sheetXML, "<f>SUM(A1:A3)</f>") {
+		t.Fatalf("formula source leaked into <v> instead of a proper <f> element:\n%s", sheetXML)
+	}
+
+	got, err := excel.ReadExcel(out)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	if len(got) != 1 || len(got[0].Typed) != 2 {
+		t.Fatalf("unexpected sheet/row shape: %+v", got)
+	}
+	cell := got[0].Typed[1][0]
+	if cell.Kind != excel.KindFormula || cell.Value != "=SUM(A1:A3)" {
+		t.Errorf("got %+v, want KindFormula \"=SUM(A1:A3)\"", cell)
+	}
+}
+
+// TestWorkbookBuilderDateRoundTrip verifies that the Workbook/Sheet builder
+// API converts a KindDate cell's ISO-8601 CellValue.Formatted into an Excel
+// serial number <v>, instead of writing the ISO string verbatim, and that it
+// reads back as the same date.
+func TestWorkbookBuilderDateRoundTrip(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "workbook_date.xlsx")
+
+	wb := excel.NewWorkbook()
+	sheet := wb.AddSheet("Sheet1")
+	sheet.SetCell(0, 0, excel.CellValue{Kind: excel.KindString, Formatted: "Shipped"})
+	sheet.SetCell(1, 0, excel.CellValue{Kind: excel.KindDate, Formatted: "2024-01-15", StyleID: excel.AutoStyle})
+
+	if err := wb.Save(out); err != nil {
+		t.Fatalf("Workbook.Save failed: %v", err)
+	}
+
+	sheetXML, err := readZipEntry(out, "xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("failed to read worksheet part: %v", err)
+	}
+	if strings.Contains(sheetXML, "<v>2024-01-15</v>") {
+		t.Fatalf("ISO date string leaked into <v> instead of an Excel serial number:\n%s", sheetXML)
+	}
+
+	got, err := excel.ReadExcel(out)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	cell := got[0].Typed[1][0]
+	if cell.Kind != excel.KindDate || cell.Value != "2024-01-15" {
+		t.Errorf("got %+v, want KindDate \"2024-01-15\"", cell)
+	}
+}
+
+// TestAlignmentRoundTrip verifies that GFM separator-row alignment markers
+// survive a Markdown -> XLSX -> Markdown round trip as Excel horizontal
+// alignment and back.
+func TestAlignmentRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "in.md")
+	xlsxPath := filepath.Join(dir, "out.xlsx")
+
+	md := "## Sheet1\n\n" +
+		"| Left | Center | Right |\n" +
+		"| :--- | :---: | ---: |\n" +
+		"| a | b | c |\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("failed to write markdown fixture: %v", err)
+	}
+
+	sheets, err := markdown.ReadMarkdown(mdPath)
+	if err != nil {
+		t.Fatalf("ReadMarkdown failed: %v", err)
+	}
+	if err := excel.WriteExcel(xlsxPath, sheets); err != nil {
+		t.Fatalf("WriteExcel failed: %v", err)
+	}
+
+	roundTripped, err := excel.ReadExcel(xlsxPath)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+
+	got := markdown.ToMarkdown(roundTripped)
+	wantMarkers := []string{":---", ":---:", "---:"}
+	for _, marker := range wantMarkers {
+		if !strings.Contains(got, marker) {
+			t.Errorf("round-tripped markdown missing alignment marker %q:\n%s", marker, got)
+		}
+	}
+}
+
+// TestLayoutRoundTrip verifies that merged cells, a frozen pane, and an
+// explicit column width - all encoded as "<!-- xlmd:... -->" directives in
+// Markdown - survive a Markdown -> XLSX -> read-back round trip.
+func TestLayoutRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "in.md")
+	xlsxPath := filepath.Join(dir, "out.xlsx")
+
+	md := "## Sheet1\n\n" +
+		"<!-- xlmd:merge A1:B1 -->\n" +
+		"<!-- xlmd:freeze A2 -->\n" +
+		"<!-- xlmd:colwidth 0=20 -->\n\n" +
+		"| Header | |\n" +
+		"| --- | --- |\n" +
+		"| a | b |\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("failed to write markdown fixture: %v", err)
+	}
+
+	sheets, err := markdown.ReadMarkdown(mdPath)
+	if err != nil {
+		t.Fatalf("ReadMarkdown failed: %v", err)
+	}
+	if err := excel.WriteExcelWithOptions(xlsxPath, sheets, excel.WriteOptions{}); err != nil {
+		t.Fatalf("WriteExcelWithOptions failed: %v", err)
+	}
+
+	got, err := excel.ReadExcel(xlsxPath)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("unexpected sheet count: %d", len(got))
+	}
+	sheet := got[0]
+	if len(sheet.Merges) != 1 || sheet.Merges[0] != (excel.MergeRange{Start: "A1", End: "B1"}) {
+		t.Errorf("got Merges %+v, want [{A1 B1}]", sheet.Merges)
+	}
+	if sheet.FreezePane != "A2" {
+		t.Errorf("got FreezePane %q, want %q", sheet.FreezePane, "A2")
+	}
+	if sheet.ColWidths[0] != 20 {
+		t.Errorf("got ColWidths[0] %v, want 20", sheet.ColWidths[0])
+	}
+}
+
+// TestSheetOrderRoundTrip verifies that ReadExcel returns sheets in
+// workbook.xml's tab order and with their real display names, for a
+// multi-sheet Markdown document written via WriteExcel.
+func TestSheetOrderRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "in.md")
+	xlsxPath := filepath.Join(dir, "out.xlsx")
+
+	md := "## Zebra\n\n" +
+		"| A |\n| --- |\n| 1 |\n\n" +
+		"## Apple\n\n" +
+		"| A |\n| --- |\n| 2 |\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("failed to write markdown fixture: %v", err)
+	}
+
+	sheets, err := markdown.ReadMarkdown(mdPath)
+	if err != nil {
+		t.Fatalf("ReadMarkdown failed: %v", err)
+	}
+	if err := excel.WriteExcel(xlsxPath, sheets); err != nil {
+		t.Fatalf("WriteExcel failed: %v", err)
+	}
+
+	got, err := excel.ReadExcel(xlsxPath)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	wantNames := []string{"Zebra", "Apple"}
+	if len(got) != len(wantNames) {
+		t.Fatalf("got %d sheets, want %d", len(got), len(wantNames))
+	}
+	for i, want := range wantNames {
+		if got[i].Name != want {
+			t.Errorf("sheet %d: got name %q, want %q", i, got[i].Name, want)
+		}
+	}
+}
+
+// TestStreamWriterLargeSheetRoundTrip exercises StreamWriter well beyond a
+// single in-memory row buffer's worth of data, verifying the streaming
+// writer doesn't drop or reorder rows for a sheet too large to comfortably
+// build via the Workbook builder.
+func TestStreamWriterLargeSheetRoundTrip(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "large.xlsx")
+	const rowCount = 2000
+
+	sw, err := excel.NewStreamWriter(out)
+	if err != nil {
+		t.Fatalf("NewStreamWriter failed: %v", err)
+	}
+	ss, err := sw.AppendSheet("Sheet1")
+	if err != nil {
+		t.Fatalf("AppendSheet failed: %v", err)
+	}
+	for i := 0; i < rowCount; i++ {
+		row := []excel.TypedCell{{Value: strconv.Itoa(i), Kind: excel.KindNumber}}
+		if err := ss.WriteTypedRow(row); err != nil {
+			t.Fatalf("WriteTypedRow failed at row %d: %v", i, err)
+		}
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	rows := 0
+	err = excel.StreamExcel(out, func(sheet string, rowIdx int, cells []string) error {
+		if cells[0] != strconv.Itoa(rows) {
+			t.Errorf("row %d: got value %q, want %q", rows, cells[0], strconv.Itoa(rows))
+		}
+		rows++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamExcel failed: %v", err)
+	}
+	if rows != rowCount {
+		t.Errorf("got %d rows, want %d", rows, rowCount)
+	}
+}
+
+// TestClassifyValueRejectsNonDecimalNumerics verifies that ClassifyValue
+// only treats plain decimal literals as KindNumber, not the wider set of
+// strings strconv.ParseFloat accepts (NaN, Inf, hex floats) - an ordinary
+// data cell containing one of those literal strings must round-trip as
+// KindString, since none of them are legal inside an OOXML <v> element.
+func TestClassifyValueRejectsNonDecimalNumerics(t *testing.T) {
+	for _, raw := range []string{"NaN", "Inf", "+Inf", "-Inf", "0x1p10"} {
+		if got := excel.ClassifyValue(raw).Kind; got != excel.KindString {
+			t.Errorf("ClassifyValue(%q).Kind = %v, want KindString", raw, got)
+		}
+	}
+	for _, raw := range []string{"42", "-3.14", "2e10", ".5"} {
+		if got := excel.ClassifyValue(raw).Kind; got != excel.KindNumber {
+			t.Errorf("ClassifyValue(%q).Kind = %v, want KindNumber", raw, got)
+		}
+	}
+}
+
+// TestDefaultStyleSheetXMLRoundTrip verifies that DefaultStyleSheet's XML
+// parses back as valid xl/styles.xml, with the bold header font and the
+// built-in date numFmt landing at the cellXfs indices StyleHeader/StyleDate
+// name.
+func TestDefaultStyleSheetXMLRoundTrip(t *testing.T) {
+	raw := excel.DefaultStyleSheet().XML()
+
+	var parsed excel.StylesXML
+	if err := xml.Unmarshal([]byte(raw), &parsed); err != nil {
+		t.Fatalf("failed to parse generated styles.xml: %v\n%s", err, raw)
+	}
+	if len(parsed.CellXfs) <= excel.StyleDate {
+		t.Fatalf("got %d cellXfs, want more than %d", len(parsed.CellXfs), excel.StyleDate)
+	}
+	if parsed.CellXfs[excel.StyleDate].NumFmtID != 14 {
+		t.Errorf("StyleDate cellXf numFmtId = %d, want 14", parsed.CellXfs[excel.StyleDate].NumFmtID)
+	}
+	if !strings.Contains(raw, "<b/>") {
+		t.Errorf("generated styles.xml has no bold font for StyleHeader:\n%s", raw)
+	}
+	if align := parsed.CellXfs[excel.StyleAlignCenter].Alignment; align == nil || align.Horizontal != "center" {
+		t.Errorf("StyleAlignCenter cellXf alignment = %+v, want horizontal=center", align)
+	}
+}
+
+// TestHyperlinkRoundTrip verifies that a Markdown "[text](url \"tip\")" link
+// cell survives Markdown -> XLSX <hyperlinks> -> Markdown without losing its
+// display text, target, or tooltip.
+func TestHyperlinkRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "in.md")
+	xlsxPath := filepath.Join(dir, "out.xlsx")
+
+	md := "## Sheet1\n\n" +
+		"| Site |\n| --- |\n" +
+		"| [Example](https://example.com \"tip\") |\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("failed to write markdown fixture: %v", err)
+	}
+
+	sheets, err := markdown.ReadMarkdown(mdPath)
+	if err != nil {
+		t.Fatalf("ReadMarkdown failed: %v", err)
+	}
+	if err := excel.WriteExcel(xlsxPath, sheets); err != nil {
+		t.Fatalf("WriteExcel failed: %v", err)
+	}
+
+	roundTripped, err := excel.ReadExcel(xlsxPath)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	cell := roundTripped[0].Typed[1][0]
+	if cell.Value != "Example" || cell.Hyperlink != "https://example.com" || cell.Tooltip != "tip" {
+		t.Errorf("got %+v, want {Value:Example Hyperlink:https://example.com Tooltip:tip}", cell)
+	}
+
+	got := markdown.ToMarkdown(roundTripped)
+	if !strings.Contains(got, `[Example](https://example.com "tip")`) {
+		t.Errorf("round-tripped markdown missing hyperlink:\n%s", got)
+	}
+}
+
+// TestColWidthRowHeightMarkdownRoundTrip verifies that "xlmd:colwidth" and
+// "xlmd:rowheight" directives survive a full Markdown -> XLSX -> Markdown
+// round trip, re-emitted as the same directives by ToMarkdown.
+func TestColWidthRowHeightMarkdownRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "in.md")
+	xlsxPath := filepath.Join(dir, "out.xlsx")
+
+	md := "## Sheet1\n\n" +
+		"<!-- xlmd:colwidth 1=30.5 -->\n" +
+		"<!-- xlmd:rowheight 0=25 -->\n\n" +
+		"| A | B |\n| --- | --- |\n| 1 | 2 |\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("failed to write markdown fixture: %v", err)
+	}
+
+	sheets, err := markdown.ReadMarkdown(mdPath)
+	if err != nil {
+		t.Fatalf("ReadMarkdown failed: %v", err)
+	}
+	if err := excel.WriteExcel(xlsxPath, sheets); err != nil {
+		t.Fatalf("WriteExcel failed: %v", err)
+	}
+
+	roundTripped, err := excel.ReadExcel(xlsxPath)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	if roundTripped[0].ColWidths[1] != 30.5 {
+		t.Errorf("got ColWidths[1] %v, want 30.5", roundTripped[0].ColWidths[1])
+	}
+	if roundTripped[0].RowHeights[0] != 25 {
+		t.Errorf("got RowHeights[0] %v, want 25", roundTripped[0].RowHeights[0])
+	}
+
+	got := markdown.ToMarkdown(roundTripped)
+	if !strings.Contains(got, "xlmd:colwidth 1=30.5") || !strings.Contains(got, "xlmd:rowheight 0=25") {
+		t.Errorf("round-tripped markdown missing colwidth/rowheight directives:\n%s", got)
+	}
+}
+
+// TestAutoFilterFrozenHeaderRoundTrip verifies that DefaultWriteOptions
+// derives a header-row auto-filter and a frozen header pane from an ordinary
+// Markdown table (which always has a header row by GFM rules), surviving the
+// XLSX read-back.
+func TestAutoFilterFrozenHeaderRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "in.md")
+	xlsxPath := filepath.Join(dir, "out.xlsx")
+
+	md := "## Sheet1\n\n| A | B |\n| --- | --- |\n| 1 | 2 |\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("failed to write markdown fixture: %v", err)
+	}
+
+	sheets, err := markdown.ReadMarkdown(mdPath)
+	if err != nil {
+		t.Fatalf("ReadMarkdown failed: %v", err)
+	}
+	if err := excel.WriteExcel(xlsxPath, sheets); err != nil {
+		t.Fatalf("WriteExcel failed: %v", err)
+	}
+
+	got, err := excel.ReadExcel(xlsxPath)
+	if err != nil {
+		t.Fatalf("ReadExcel failed: %v", err)
+	}
+	if !got[0].AutoFilter {
+		t.Error("got AutoFilter=false, want true")
+	}
+	if got[0].FreezePane != "A2" {
+		t.Errorf("got FreezePane %q, want %q", got[0].FreezePane, "A2")
+	}
+}